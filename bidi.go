@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// Direction 文本方向（"ltr" 或 "rtl"），可直接用作 HTML 的 dir 属性
+type Direction = internal.Direction
+
+const (
+	LeftToRight = internal.LeftToRight
+	RightToLeft = internal.RightToLeft
+)
+
+// LanguageDirection 返回语言的文本方向，如 ar、he、fa -> rtl；en、zh-CN -> ltr
+func LanguageDirection(lang string) Direction {
+	return internal.LanguageDirection(languageTag(lang))
+}
+
+// Isolate 用 Unicode 隔离符（FSI ... PDI）包裹插入消息的值，避免混合的 LTR/RTL 文本显示错乱，
+// 如希伯来语消息中的英文用户名或带标点的数字；消息模板中可以使用 {{isolate .Name}}
+func Isolate(v interface{}) string {
+	return internal.Isolate(v)
+}
+
+// GetDirection 获取当前语言的文本方向
+func (s *Service) GetDirection(ctx context.Context) Direction {
+	return s.translator.Direction(s.GetLanguage(ctx))
+}
+
+// GetDirectionFromGin 从 Gin Context 获取文本方向，使用中间件时为中间件检测的结果
+func (s *Service) GetDirectionFromGin(c *gin.Context) Direction {
+	if dir, ok := c.Get(string(DirectionKey)); ok {
+		if d, ok := dir.(Direction); ok {
+			return d
+		}
+	}
+	return s.translator.Direction(s.GetLanguageFromGin(c))
+}
+
+// GetDirection 获取当前语言的文本方向
+func GetDirection(ctx context.Context) Direction {
+	return GetService().GetDirection(ctx)
+}
+
+// GetDirectionFromGin 从 Gin Context 获取文本方向
+func GetDirectionFromGin(c *gin.Context) Direction {
+	return GetService().GetDirectionFromGin(c)
+}
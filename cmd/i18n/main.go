@@ -0,0 +1,451 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+
+	"github.com/chenguowei/go-i18n/convert"
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// commands 支持的子命令，键为 "命令 子命令"
+var commands = map[string]func(args []string) error{
+	"gettext export": gettextExport,
+	"xliff export":   xliffExport,
+	"xliff import":   xliffImport,
+	"arb export":     arbExport,
+	"arb import":     arbImport,
+	"chrome export":  chromeExport,
+	"chrome import":  chromeImport,
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 3 {
+		usage()
+		os.Exit(1)
+	}
+
+	command, ok := commands[os.Args[1]+" "+os.Args[2]]
+	if !ok {
+		usage()
+		os.Exit(1)
+	}
+
+	if err := command(os.Args[3:]); err != nil {
+		log.Fatalf("%s %s: %v", os.Args[1], os.Args[2], err)
+	}
+}
+
+func usage() {
+	fmt.Println("Usage: i18n <command> <subcommand> [flags]")
+	fmt.Println("\nCommands:")
+	fmt.Println("  gettext export   Export locale files to messages.pot and <lang>.po")
+	fmt.Println("  xliff export     Export one XLIFF file per target language")
+	fmt.Println("  xliff import     Import translated XLIFF files into the locale directory")
+	fmt.Println("  arb export       Export locale files to Flutter ARB files")
+	fmt.Println("  arb import       Import Flutter ARB files into the locale directory")
+	fmt.Println("  chrome export    Export locale files to Chrome _locales/<lang>/messages.json")
+	fmt.Println("  chrome import    Import Chrome messages.json files into the locale directory")
+	fmt.Println("\nRun 'i18n <command> <subcommand> -h' for flags.")
+}
+
+// localeFlags 加载语言文件的公共参数
+type localeFlags struct {
+	path      string
+	mode      string
+	languages string
+}
+
+func (f *localeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "locales", "locales", "语言文件目录")
+	fs.StringVar(&f.mode, "mode", "", "语言文件组织模式 flat/nested（默认自动检测）")
+	fs.StringVar(&f.languages, "languages", "", "只处理指定语言，逗号分隔（默认全部语言）")
+}
+
+// load 加载语言文件，返回加载器
+func (f *localeFlags) load() (*internal.LocaleLoader, error) {
+	loader := internal.NewLocaleLoader(internal.LocaleLoaderConfig{
+		Mode:      internal.LocaleMode(f.mode),
+		Path:      f.path,
+		Languages: splitList(f.languages),
+	}, i18n.NewBundle(language.English))
+
+	if err := loader.LoadLocales(); err != nil {
+		return nil, err
+	}
+	if len(loader.Languages()) == 0 {
+		return nil, fmt.Errorf("no locale files found in %s", f.path)
+	}
+	return loader, nil
+}
+
+// gettextExport 导出 POT 模板和每种语言的 PO 文件
+func gettextExport(args []string) error {
+	var locales localeFlags
+	fs := flag.NewFlagSet("gettext export", flag.ExitOnError)
+	locales.register(fs)
+	sourceLang := fs.String("source-language", "en", "源语言，其文本作为 msgid")
+	outDir := fs.String("out", "po", "输出目录")
+	fs.Parse(args)
+
+	loader, err := locales.load()
+	if err != nil {
+		return err
+	}
+
+	source := loader.Messages(*sourceLang)
+	if len(source) == 0 {
+		return fmt.Errorf("no messages found for source language %s", *sourceLang)
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+
+	pot, err := internal.EncodePO(*sourceLang, source, nil)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(*outDir, "messages.pot"), pot); err != nil {
+		return err
+	}
+
+	for _, lang := range loader.Languages() {
+		data, err := internal.EncodePO(lang, source, loader.Messages(lang))
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", lang, err)
+		}
+		if err := writeFile(filepath.Join(*outDir, lang+".po"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xliffExport 为每种目标语言导出 XLIFF 文件
+func xliffExport(args []string) error {
+	var locales localeFlags
+	fs := flag.NewFlagSet("xliff export", flag.ExitOnError)
+	locales.register(fs)
+	sourceLang := fs.String("source-language", "en", "源语言，其文本作为 <source>")
+	targetLangs := fs.String("target-languages", "", "目标语言，逗号分隔（默认为除源语言外的全部语言）")
+	version := fs.String("version", internal.XLIFFVersion12, "XLIFF 版本 1.2/2.0")
+	state := fs.String("state", string(internal.XLIFFStateTranslated), "已有译文的状态 translated/final")
+	outDir := fs.String("out", "xliff", "输出目录")
+	fs.Parse(args)
+
+	translatedState, err := internal.ParseXLIFFState(*state)
+	if err != nil {
+		return err
+	}
+
+	loader, err := locales.load()
+	if err != nil {
+		return err
+	}
+
+	source := loader.Messages(*sourceLang)
+	if len(source) == 0 {
+		return fmt.Errorf("no messages found for source language %s", *sourceLang)
+	}
+
+	targets := splitList(*targetLangs)
+	if len(targets) == 0 {
+		for _, lang := range loader.Languages() {
+			if !strings.EqualFold(lang, *sourceLang) {
+				targets = append(targets, lang)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+
+	moduleOf := func(id string) string {
+		return loader.MessageModule(*sourceLang, id)
+	}
+	for _, lang := range targets {
+		doc, err := internal.NewXLIFFDocument(*sourceLang, lang, source, loader.Messages(lang), moduleOf, translatedState)
+		if err != nil {
+			return err
+		}
+		data, err := internal.EncodeXLIFF(doc, *version)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(*outDir, lang+".xlf"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xliffImport 将 XLIFF 文件中的译文写回语言目录
+func xliffImport(args []string) error {
+	fs := flag.NewFlagSet("xliff import", flag.ExitOnError)
+	path := fs.String("locales", "locales", "语言文件目录")
+	mode := fs.String("mode", "", "写入的组织模式 flat/nested（默认自动检测）")
+	minState := fs.String("min-state", string(internal.XLIFFStateTranslated), "导入的最低状态 new/translated/final")
+	dryRun := fs.Bool("dry-run", false, "只显示将要写入的文件")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no xliff files given")
+	}
+
+	state, err := internal.ParseXLIFFState(*minState)
+	if err != nil {
+		return err
+	}
+
+	loader := internal.NewLocaleLoader(internal.LocaleLoaderConfig{
+		Mode: internal.LocaleMode(*mode),
+		Path: *path,
+	}, nil)
+
+	for _, filename := range fs.Args() {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		doc, err := internal.ParseXLIFF(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+		if doc.TargetLang == "" {
+			return fmt.Errorf("%s has no target language", filename)
+		}
+
+		written, err := loader.WriteMessages(doc.TargetLang, doc.Messages(state), *dryRun)
+		if err != nil {
+			return err
+		}
+
+		verb := "Wrote"
+		if *dryRun {
+			verb = "Would write"
+		}
+		for _, target := range written {
+			fmt.Printf("  %s %s\n", verb, target)
+		}
+	}
+	return nil
+}
+
+// arbExport 为每种语言导出 ARB 文件
+func arbExport(args []string) error {
+	var locales localeFlags
+	fs := flag.NewFlagSet("arb export", flag.ExitOnError)
+	locales.register(fs)
+	prefix := fs.String("prefix", "app", "ARB 文件名前缀，生成 <prefix>_<lang>.arb")
+	outDir := fs.String("out", "l10n", "输出目录")
+	fs.Parse(args)
+
+	loader, err := locales.load()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+
+	for _, lang := range loader.Languages() {
+		data, err := convert.ToARB(underscoreLocale(lang), loader.Messages(lang))
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", lang, err)
+		}
+		filename := filepath.Join(*outDir, *prefix+"_"+underscoreLocale(lang)+".arb")
+		if err := writeFile(filename, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arbImport 将 ARB 文件写回语言目录
+// 语言取自 @@locale，未声明时取文件名中最后一个 _ 之前的前缀之后的部分（如 app_zh_CN.arb）
+func arbImport(args []string) error {
+	var target importFlags
+	fs := flag.NewFlagSet("arb import", flag.ExitOnError)
+	target.register(fs)
+	prefix := fs.String("prefix", "app", "ARB 文件名前缀，用于从文件名推断语言")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no arb files given")
+	}
+
+	for _, filename := range fs.Args() {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		lang, messages, err := convert.FromARB(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+		if lang == "" {
+			name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+			lang = strings.TrimPrefix(name, *prefix+"_")
+		}
+
+		if err := target.write(hyphenLocale(lang), messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chromeExport 为每种语言导出 Chrome messages.json
+func chromeExport(args []string) error {
+	var locales localeFlags
+	fs := flag.NewFlagSet("chrome export", flag.ExitOnError)
+	locales.register(fs)
+	outDir := fs.String("out", "_locales", "输出目录")
+	fs.Parse(args)
+
+	loader, err := locales.load()
+	if err != nil {
+		return err
+	}
+
+	for _, lang := range loader.Languages() {
+		data, err := convert.ToChrome(loader.Messages(lang))
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", lang, err)
+		}
+		dir := filepath.Join(*outDir, underscoreLocale(lang))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(dir, "messages.json"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chromeImport 将 Chrome messages.json 写回语言目录，语言取自文件所在目录名
+// 参数可以是 messages.json 文件或 _locales 目录
+func chromeImport(args []string) error {
+	var target importFlags
+	fs := flag.NewFlagSet("chrome import", flag.ExitOnError)
+	target.register(fs)
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no chrome messages files given")
+	}
+
+	var files []string
+	for _, arg := range fs.Args() {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*", "messages.json"))
+		if err != nil {
+			return err
+		}
+		files = append(files, matches...)
+	}
+
+	for _, filename := range files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		messages, err := convert.FromChrome(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+
+		lang := filepath.Base(filepath.Dir(filename))
+		if err := target.write(hyphenLocale(lang), messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importFlags 写回语言目录的公共参数
+type importFlags struct {
+	path   string
+	mode   string
+	module string
+	dryRun bool
+}
+
+func (f *importFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "locales", "locales", "语言文件目录")
+	fs.StringVar(&f.mode, "mode", "", "写入的组织模式 flat/nested（默认自动检测）")
+	fs.StringVar(&f.module, "module", "common", "分层模式下写入的模块")
+	fs.BoolVar(&f.dryRun, "dry-run", false, "只显示将要写入的文件")
+}
+
+// write 将消息合并写入语言目录
+func (f *importFlags) write(lang string, messages []*i18n.Message) error {
+	loader := internal.NewLocaleLoader(internal.LocaleLoaderConfig{
+		Mode: internal.LocaleMode(f.mode),
+		Path: f.path,
+	}, nil)
+
+	written, err := loader.WriteMessages(lang, map[string][]*i18n.Message{f.module: messages}, f.dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Wrote"
+	if f.dryRun {
+		verb = "Would write"
+	}
+	for _, target := range written {
+		fmt.Printf("  %s %s\n", verb, target)
+	}
+	return nil
+}
+
+// underscoreLocale 转换为 Flutter/Chrome 使用的下划线形式（zh-CN -> zh_CN）
+func underscoreLocale(lang string) string {
+	return strings.ReplaceAll(lang, "-", "_")
+}
+
+// hyphenLocale 转换为语言目录使用的连字符形式（zh_CN -> zh-CN）
+func hyphenLocale(lang string) string {
+	return strings.ReplaceAll(lang, "_", "-")
+}
+
+// writeFile 写入文件并输出路径
+func writeFile(filename string, data []byte) error {
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("  Wrote %s\n", filename)
+	return nil
+}
+
+// splitList 解析逗号分隔的列表，忽略空项
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
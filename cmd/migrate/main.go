@@ -1,24 +1,35 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 
 	"github.com/chenguowei/go-i18n/internal"
 )
 
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: migrate <source_path> <target_mode> [modules]")
-		fmt.Println("Example: migrate locales flat")
-		fmt.Println("Example: migrate locales nested common,errors,ui")
+	dryRun := flag.Bool("dry-run", false, "只显示迁移计划，不修改文件")
+	languages := flag.String("languages", "", "只迁移指定语言，逗号分隔（默认迁移全部语言）")
+	mappingFile := flag.String("mapping", "", "模块映射文件（JSON/YAML: 模块名 -> 消息ID列表，支持 PREFIX_* 前缀）")
+	defaultModule := flag.String("default-module", "common", "拆分时无法归类的消息写入的模块")
+	noBackup := flag.Bool("no-backup", false, "不备份原文件")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
 		os.Exit(1)
 	}
 
-	sourcePath := os.Args[1]
-	targetMode := internal.LocaleMode(os.Args[2])
+	sourcePath := flag.Arg(0)
+	targetMode := internal.LocaleMode(flag.Arg(1))
 
 	// 验证源路径
 	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
@@ -41,33 +52,59 @@ func main() {
 
 	// 解析模块列表（仅用于嵌套模式）
 	var modules []string
-	if targetMode == internal.NestedMode && len(os.Args) > 3 {
-		modules = splitModules(os.Args[3])
+	if targetMode == internal.NestedMode && flag.NArg() > 2 {
+		modules = splitModules(flag.Arg(2))
 	}
 
 	// 创建迁移配置
 	migrationConfig := internal.MigrationConfig{
-		FromMode: currentMode,
-		ToMode:   targetMode,
-		Modules:  modules,
+		FromMode:      currentMode,
+		ToMode:        targetMode,
+		Modules:       modules,
+		DefaultModule: *defaultModule,
+		DryRun:        *dryRun,
+	}
+
+	if *mappingFile != "" {
+		mapping, err := loadMapping(*mappingFile)
+		if err != nil {
+			log.Fatalf("Failed to load mapping file: %v", err)
+		}
+		migrationConfig.ModuleMapping = mapping
+	}
+
+	if !*noBackup {
+		migrationConfig.BackupDir = fmt.Sprintf("%s.bak-%s",
+			filepath.Clean(sourcePath), time.Now().Format("20060102150405"))
 	}
 
 	// 创建加载器
 	loaderConfig := internal.LocaleLoaderConfig{
 		Mode:      currentMode,
 		Path:      sourcePath,
-		Languages: []string{"en", "zh-CN", "zh-TW"}, // 可以从参数或配置获取
-		Modules:   modules,
+		Languages: splitList(*languages),
 	}
 
 	loader := internal.NewLocaleLoader(loaderConfig, nil)
 
 	// 执行迁移
-	fmt.Println("Starting migration...")
-	if err := loader.MigrateLocaleStructure(migrationConfig); err != nil {
+	if *dryRun {
+		fmt.Println("Dry run, no files will be changed.")
+	} else {
+		fmt.Println("Starting migration...")
+	}
+
+	result, err := loader.MigrateLocaleStructure(migrationConfig)
+	if err != nil {
 		log.Fatalf("Migration failed: %v", err)
 	}
 
+	printResult(result, *dryRun)
+
+	if *dryRun {
+		return
+	}
+
 	fmt.Println("Migration completed successfully!")
 
 	// 显示结果
@@ -75,10 +112,68 @@ func main() {
 	printTree(sourcePath, targetMode)
 }
 
+func usage() {
+	fmt.Println("Usage: migrate [flags] <source_path> <target_mode> [modules]")
+	fmt.Println("Example: migrate locales flat")
+	fmt.Println("Example: migrate locales nested common,errors,ui")
+	fmt.Println("Example: migrate --dry-run --languages en,zh-CN --mapping modules.yaml locales nested")
+	fmt.Println("\nFlags:")
+	flag.PrintDefaults()
+}
+
+// splitModules 解析逗号分隔的模块列表
 func splitModules(modulesStr string) []string {
-	// 简单的逗号分割
-	// 实际实现可能需要更复杂的解析
-	return []string{"common", "errors", "ui", "emails"}
+	return splitList(modulesStr)
+}
+
+// splitList 解析逗号分隔的列表，忽略空项
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadMapping 加载模块映射文件
+func loadMapping(filename string) (map[string][]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON 是 YAML 的子集，统一使用 YAML 解析
+	var mapping map[string][]string
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return mapping, nil
+}
+
+func printResult(result *internal.MigrationResult, dryRun bool) {
+	writeVerb, removeVerb := "Wrote", "Removed"
+	if dryRun {
+		writeVerb, removeVerb = "Would write", "Would remove"
+	}
+
+	for _, path := range result.Written {
+		fmt.Printf("  %s %s\n", writeVerb, path)
+	}
+	for _, path := range result.Removed {
+		fmt.Printf("  %s %s\n", removeVerb, path)
+	}
+	if result.BackupDir != "" {
+		fmt.Printf("Original files backed up to %s\n", result.BackupDir)
+	}
+
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("\n%d conflict(s) found, the first definition was kept:\n", len(result.Conflicts))
+		for _, conflict := range result.Conflicts {
+			fmt.Printf("  %s\n", conflict)
+		}
+	}
 }
 
 func printTree(path string, mode internal.LocaleMode) {
@@ -87,10 +182,10 @@ func printTree(path string, mode internal.LocaleMode) {
 			return err
 		}
 
-		if !info.IsDir() && filepath.Ext(filePath) == ".json" {
+		if !info.IsDir() && internal.IsLocaleFile(filePath) {
 			relPath, _ := filepath.Rel(path, filePath)
 			fmt.Printf("  %s\n", relPath)
 		}
 		return nil
 	})
-}
\ No newline at end of file
+}
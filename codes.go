@@ -22,6 +22,7 @@ type Response struct {
 type Meta struct {
 	RequestID  string      `json:"request_id,omitempty"`
 	Language   string      `json:"language,omitempty"`
+	Dir        Direction   `json:"dir,omitempty"`
 	Timestamp  time.Time   `json:"timestamp"`
 	TraceID    string      `json:"trace_id,omitempty"`
 	Version    string      `json:"version,omitempty"`
@@ -2,12 +2,16 @@ package i18n
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/chenguowei/go-i18n/internal"
 )
 
 // LoadConfig 加载配置
@@ -67,6 +71,22 @@ func loadFromEnv(config *Config) error {
 		config.Debug = parseBool(val, false)
 	}
 
+	if val := os.Getenv("I18N_STRICT"); val != "" {
+		config.Strict = parseBool(val, false)
+	}
+
+	if val := os.Getenv("I18N_PSEUDO_LOCALIZATION"); val != "" {
+		config.PseudoLocalization = parseBool(val, false)
+	}
+
+	if val := os.Getenv("I18N_TEMPLATE_CHECK"); val != "" {
+		config.TemplateCheck = val
+	}
+
+	if val := os.Getenv("I18N_OVERRIDES_FILE"); val != "" {
+		config.OverridesFile = val
+	}
+
 	if val := os.Getenv("I18N_ENABLE_METRICS"); val != "" {
 		config.EnableMetrics = parseBool(val, false)
 	}
@@ -142,6 +162,16 @@ func ValidateConfig(config Config) error {
 		return fmt.Errorf("locales_path cannot be empty")
 	}
 
+	switch config.LocaleConfig.MessageFormat {
+	case "", "template", "icu":
+	default:
+		return fmt.Errorf("unsupported message_format: %s", config.LocaleConfig.MessageFormat)
+	}
+
+	if _, err := internal.ParseTemplateCheckMode(config.TemplateCheck); err != nil {
+		return err
+	}
+
 	// 验证缓存配置
 	if config.Cache.Enable {
 		if config.Cache.Size <= 0 {
@@ -196,9 +226,36 @@ func MergeConfigs(configs ...Config) Config {
 		if config.FallbackLanguage != "" {
 			result.FallbackLanguage = config.FallbackLanguage
 		}
+		for lang, chain := range config.FallbackChains {
+			if result.FallbackChains == nil {
+				result.FallbackChains = make(map[string][]string)
+			}
+			result.FallbackChains[lang] = chain
+		}
 		if config.LocalesPath != "" {
 			result.LocalesPath = config.LocalesPath
 		}
+		if config.MissingHandler != nil {
+			result.MissingHandler = config.MissingHandler
+		}
+		if config.MissingLimit > 0 {
+			result.MissingLimit = config.MissingLimit
+		}
+		if config.FS != nil {
+			result.FS = config.FS
+		}
+		if config.LocaleConfig.KeySeparator != "" {
+			result.LocaleConfig.KeySeparator = config.LocaleConfig.KeySeparator
+		}
+		if config.LocaleConfig.Namespaces {
+			result.LocaleConfig.Namespaces = true
+		}
+		if config.LocaleConfig.DefaultNamespace != "" {
+			result.LocaleConfig.DefaultNamespace = config.LocaleConfig.DefaultNamespace
+		}
+		if config.LocaleConfig.MessageFormat != "" {
+			result.LocaleConfig.MessageFormat = config.LocaleConfig.MessageFormat
+		}
 
 		// 合并缓存配置
 		if config.Cache.Enable {
@@ -229,6 +286,14 @@ func MergeConfigs(configs ...Config) Config {
 
 		// 其他配置
 		result.Debug = config.Debug
+		result.Strict = config.Strict
+		result.PseudoLocalization = config.PseudoLocalization
+		if config.TemplateCheck != "" {
+			result.TemplateCheck = config.TemplateCheck
+		}
+		if config.OverridesFile != "" {
+			result.OverridesFile = config.OverridesFile
+		}
 		result.EnableMetrics = config.EnableMetrics
 		result.EnableWatcher = config.EnableWatcher
 	}
@@ -311,11 +376,27 @@ func validateLanguageCodes(config Config) error {
 		return fmt.Errorf("invalid fallback language code: %s", config.FallbackLanguage)
 	}
 
+	// 验证降级链中的语言代码
+	for lang, chain := range config.FallbackChains {
+		for _, code := range append([]string{lang}, chain...) {
+			if !IsValidLanguageCode(code) {
+				return fmt.Errorf("invalid language code in fallback chain %s: %s", lang, code)
+			}
+		}
+	}
+
 	return nil
 }
 
 func validatePaths(config Config) error {
 	// 检查路径是否存在
+	if config.FS != nil {
+		if _, err := fs.Stat(config.FS, path.Clean(config.LocalesPath)); err != nil {
+			return fmt.Errorf("locales path does not exist: %s", config.LocalesPath)
+		}
+		return nil
+	}
+
 	if _, err := os.Stat(config.LocalesPath); os.IsNotExist(err) {
 		return fmt.Errorf("locales path does not exist: %s", config.LocalesPath)
 	}
@@ -0,0 +1,271 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// ARB 与消息的映射规则:
+//   - 消息ID作为资源键，描述和占位符写入 "@键" 元数据，语言写入 "@@locale"
+//   - 模板变量 {{.Name}} 对应 ICU 占位符 {Name}
+//   - 复数消息对应 {count, plural, one {...} other {...}}，复数变量取自模板中的计数变量
+
+// arbMetadata ARB 资源的元数据
+type arbMetadata struct {
+	Description  string                    `json:"description,omitempty"`
+	Placeholders map[string]arbPlaceholder `json:"placeholders,omitempty"`
+}
+
+// arbPlaceholder ARB 占位符
+type arbPlaceholder struct {
+	Type string `json:"type,omitempty"`
+}
+
+// defaultPluralVar 复数消息没有计数变量时使用的变量名
+const defaultPluralVar = "count"
+
+// ToARB 将消息转换为 ARB 文件
+func ToARB(lang string, messages []*i18n.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	writeMember := func(key string, value interface{}) error {
+		data, err := marshalJSON(value)
+		if err != nil {
+			return err
+		}
+		if buf.Len() > 1 {
+			buf.WriteString(",")
+		}
+		keyData, _ := marshalJSON(key)
+		buf.Write(keyData)
+		buf.WriteString(":")
+		buf.Write(data)
+		return nil
+	}
+
+	if err := writeMember("@@locale", lang); err != nil {
+		return nil, err
+	}
+
+	for _, m := range messages {
+		text, placeholders, err := messageToICU(m)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", m.ID, err)
+		}
+		if err := writeMember(m.ID, text); err != nil {
+			return nil, err
+		}
+
+		meta := arbMetadata{Description: m.Description}
+		if len(placeholders) > 0 {
+			meta.Placeholders = placeholders
+		}
+		if meta.Description != "" || meta.Placeholders != nil {
+			if err := writeMember("@"+m.ID, meta); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buf.WriteString("}")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// marshalJSON 编码 JSON，不转义 HTML 字符
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// messageToICU 将消息转换为 ICU MessageFormat 文本，并返回使用的占位符
+func messageToICU(m *i18n.Message) (string, map[string]arbPlaceholder, error) {
+	placeholders := make(map[string]arbPlaceholder)
+
+	if !internal.IsPluralMessage(m) {
+		text, err := templateToICU(m.Other, "", placeholders)
+		return text, placeholders, err
+	}
+
+	pluralVar := detectPluralVar(m)
+	placeholders[pluralVar] = arbPlaceholder{Type: "int"}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "{%s, plural,", pluralVar)
+	for _, form := range internal.MessageForms(m) {
+		text, err := templateToICU(form.Value, pluralVar, placeholders)
+		if err != nil {
+			return "", nil, err
+		}
+		fmt.Fprintf(&b, " %s{%s}", form.Name, text)
+	}
+	b.WriteString("}")
+	return b.String(), placeholders, nil
+}
+
+// detectPluralVar 推断复数消息的计数变量
+// 只有一个变量时使用该变量，多个变量时优先使用名为 count 的变量
+func detectPluralVar(m *i18n.Message) string {
+	var vars []string
+	seen := make(map[string]bool)
+	for _, form := range internal.MessageForms(m) {
+		for _, v := range templateVariables(form.Value) {
+			if !seen[v] {
+				seen[v] = true
+				vars = append(vars, v)
+			}
+		}
+	}
+
+	if len(vars) == 1 {
+		return vars[0]
+	}
+	for _, v := range vars {
+		if strings.EqualFold(v, defaultPluralVar) {
+			return v
+		}
+	}
+	return defaultPluralVar
+}
+
+// templateToICU 将 go-i18n 模板转换为 ICU 文本，并记录使用的占位符
+// inPlural 为复数变量名时，文本中的 # 需要转义
+func templateToICU(s, inPlural string, placeholders map[string]arbPlaceholder) (string, error) {
+	segments, err := splitTemplate(s)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, seg := range segments {
+		if seg.variable != "" {
+			if _, exists := placeholders[seg.variable]; !exists {
+				placeholders[seg.variable] = arbPlaceholder{}
+			}
+			b.WriteString("{" + seg.variable + "}")
+			continue
+		}
+		b.WriteString(escapeICU(seg.text, inPlural != ""))
+	}
+	return b.String(), nil
+}
+
+// escapeICU 转义 ICU 文本中的特殊字符
+// 单独的撇号保持原样，只有紧跟特殊字符时才需要双写
+func escapeICU(s string, inPlural bool) string {
+	special := func(c byte) bool {
+		return c == '{' || c == '}' || c == '\'' || (inPlural && c == '#')
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(s) && special(s[i+1]) {
+				b.WriteString("''")
+			} else {
+				b.WriteByte(c)
+			}
+		case special(c):
+			b.WriteString("'" + string(c) + "'")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// FromARB 解析 ARB 文件，返回其中声明的语言（@@locale，可能为空）和消息
+// 消息顺序与文件中的资源顺序一致
+func FromARB(data []byte) (string, []*i18n.Message, error) {
+	keys, values, err := decodeOrderedObject(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid arb: %w", err)
+	}
+
+	lang := ""
+	metadata := make(map[string]arbMetadata)
+	for i, key := range keys {
+		switch {
+		case key == "@@locale":
+			if err := json.Unmarshal(values[i], &lang); err != nil {
+				return "", nil, fmt.Errorf("invalid @@locale: %w", err)
+			}
+		case strings.HasPrefix(key, "@@"):
+			// 其他全局属性
+		case strings.HasPrefix(key, "@"):
+			var meta arbMetadata
+			if err := json.Unmarshal(values[i], &meta); err != nil {
+				return "", nil, fmt.Errorf("invalid metadata %s: %w", key, err)
+			}
+			metadata[key[1:]] = meta
+		}
+	}
+
+	var messages []*i18n.Message
+	for i, key := range keys {
+		if strings.HasPrefix(key, "@") {
+			continue
+		}
+
+		var text string
+		if err := json.Unmarshal(values[i], &text); err != nil {
+			return "", nil, fmt.Errorf("resource %s is not a string: %w", key, err)
+		}
+
+		m, err := internal.ICUToTemplate(text)
+		if err != nil {
+			return "", nil, fmt.Errorf("resource %s: %w", key, err)
+		}
+		m.ID = key
+		m.Description = metadata[key].Description
+		messages = append(messages, m)
+	}
+	return lang, messages, nil
+}
+
+// decodeOrderedObject 解码 JSON 对象，保持键的顺序
+func decodeOrderedObject(data []byte) ([]string, []json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var keys []string
+	var values []json.RawMessage
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
@@ -0,0 +1,191 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Chrome messages.json 与消息的映射规则:
+//   - 消息ID作为消息名，名称中不允许的字符替换为 _
+//   - 模板变量 {{.Name}} 对应具名占位符 $Name$，内容按首次出现的顺序为 $1、$2...
+//   - Chrome 不支持复数，复数消息只导出 other 形式
+
+// chromeMessage Chrome 消息
+type chromeMessage struct {
+	Message      string                       `json:"message"`
+	Description  string                       `json:"description,omitempty"`
+	Placeholders map[string]chromePlaceholder `json:"placeholders,omitempty"`
+}
+
+// chromePlaceholder Chrome 占位符
+type chromePlaceholder struct {
+	Content string `json:"content"`
+	Example string `json:"example,omitempty"`
+}
+
+// chromeNamePattern Chrome 消息名中不允许的字符
+var chromeNamePattern = regexp.MustCompile(`[^A-Za-z0-9_@]`)
+
+// ChromeMessageName 将消息ID转换为合法的 Chrome 消息名
+func ChromeMessageName(id string) string {
+	return chromeNamePattern.ReplaceAllString(id, "_")
+}
+
+// ToChrome 将消息转换为 Chrome messages.json
+func ToChrome(messages []*i18n.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+
+	names := make(map[string]string, len(messages))
+	for _, m := range messages {
+		name := ChromeMessageName(m.ID)
+		if other, exists := names[strings.ToLower(name)]; exists {
+			return nil, fmt.Errorf("messages %s and %s map to the same chrome message name %s", other, m.ID, name)
+		}
+		names[strings.ToLower(name)] = m.ID
+
+		msg, err := messageToChrome(m)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", m.ID, err)
+		}
+
+		data, err := marshalJSON(msg)
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 1 {
+			buf.WriteString(",")
+		}
+		nameData, _ := marshalJSON(name)
+		buf.Write(nameData)
+		buf.WriteString(":")
+		buf.Write(data)
+	}
+	buf.WriteString("}")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// messageToChrome 将消息转换为 Chrome 消息
+func messageToChrome(m *i18n.Message) (chromeMessage, error) {
+	segments, err := splitTemplate(m.Other)
+	if err != nil {
+		return chromeMessage{}, err
+	}
+
+	msg := chromeMessage{Description: m.Description}
+	var b strings.Builder
+	for _, seg := range segments {
+		if seg.variable == "" {
+			b.WriteString(strings.ReplaceAll(seg.text, "$", "$$"))
+			continue
+		}
+
+		if msg.Placeholders == nil {
+			msg.Placeholders = make(map[string]chromePlaceholder)
+		}
+		if _, exists := msg.Placeholders[seg.variable]; !exists {
+			msg.Placeholders[seg.variable] = chromePlaceholder{
+				Content: "$" + strconv.Itoa(len(msg.Placeholders)+1),
+			}
+		}
+		b.WriteString("$" + seg.variable + "$")
+	}
+	msg.Message = b.String()
+	return msg, nil
+}
+
+// FromChrome 解析 Chrome messages.json，消息顺序与文件中的顺序一致
+// 内容为 $1 等位置参数的占位符转换为同名模板变量，未声明的位置参数 $n 转换为 {{.Argn}}
+func FromChrome(data []byte) ([]*i18n.Message, error) {
+	keys, values, err := decodeOrderedObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chrome messages: %w", err)
+	}
+
+	messages := make([]*i18n.Message, 0, len(keys))
+	for i, key := range keys {
+		var msg chromeMessage
+		if err := json.Unmarshal(values[i], &msg); err != nil {
+			return nil, fmt.Errorf("invalid message %s: %w", key, err)
+		}
+
+		text, err := chromeToTemplate(msg)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", key, err)
+		}
+		messages = append(messages, &i18n.Message{
+			ID:          key,
+			Description: msg.Description,
+			Other:       text,
+		})
+	}
+	return messages, nil
+}
+
+// chromeToTemplate 将 Chrome 消息文本转换为 go-i18n 模板
+func chromeToTemplate(msg chromeMessage) (string, error) {
+	return expandChrome(msg.Message, func(name string) (string, error) {
+		for key, placeholder := range msg.Placeholders {
+			if !strings.EqualFold(key, name) {
+				continue
+			}
+			if isPositional(placeholder.Content) {
+				return templateVar(key), nil
+			}
+			// 字面内容中仍可能引用位置参数
+			return expandChrome(placeholder.Content, nil)
+		}
+		return "", fmt.Errorf("undeclared placeholder $%s$", name)
+	})
+}
+
+// expandChrome 展开 $$、$n 和 $NAME$，resolve 为 nil 时不允许具名占位符
+func expandChrome(s string, resolve func(name string) (string, error)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		rest := s[i+1:]
+		switch {
+		case strings.HasPrefix(rest, "$"):
+			b.WriteByte('$')
+			i++
+		case len(rest) > 0 && rest[0] >= '1' && rest[0] <= '9':
+			b.WriteString(templateVar("Arg" + rest[:1]))
+			i++
+		default:
+			end := strings.IndexByte(rest, '$')
+			if resolve == nil || end <= 0 || chromeNamePattern.MatchString(rest[:end]) {
+				b.WriteByte('$')
+				continue
+			}
+			text, err := resolve(rest[:end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(text)
+			i += end + 1
+		}
+	}
+	return b.String(), nil
+}
+
+// isPositional 检查占位符内容是否为位置参数 $1-$9
+func isPositional(content string) bool {
+	return len(content) == 2 && content[0] == '$' && content[1] >= '1' && content[1] <= '9'
+}
@@ -0,0 +1,122 @@
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessages = []*i18n.Message{
+	{ID: "WELCOME", Description: "Greeting on the home page", Other: "Hello, {{.Name}}!"},
+	{ID: "ITEMS", One: "{{.Count}} item in {{.Cart}}", Other: "{{.Count}} items in {{.Cart}}"},
+	{ID: "PRICE", Other: "Costs $5 {not a placeholder} isn't it"},
+}
+
+func TestARBRoundTrip(t *testing.T) {
+	data, err := ToARB("en", testMessages)
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "en", raw["@@locale"])
+	assert.Equal(t, "Hello, {Name}!", raw["WELCOME"])
+	assert.Equal(t, "{Count, plural, one{{Count} item in {Cart}} other{{Count} items in {Cart}}}", raw["ITEMS"])
+	assert.Equal(t, "Costs $5 '{'not a placeholder'}' isn't it", raw["PRICE"])
+	assert.Equal(t, map[string]interface{}{
+		"placeholders": map[string]interface{}{
+			"Count": map[string]interface{}{"type": "int"},
+			"Cart":  map[string]interface{}{},
+		},
+	}, raw["@ITEMS"])
+
+	lang, messages, err := FromARB(data)
+	require.NoError(t, err)
+	assert.Equal(t, "en", lang)
+	assert.Equal(t, testMessages, messages)
+}
+
+func TestFromARB(t *testing.T) {
+	data := `{
+  "@@locale": "fr",
+  "@@last_modified": "2024-01-01",
+  "inbox": "Vous avez {n, plural, =0{aucun message} one{# message} other{# messages}}.",
+  "@inbox": {"description": "Inbox count", "placeholders": {"n": {"type": "int"}}},
+  "total": "Total : {amount, number, currency}",
+  "quote": "L''option '{'x'}'"
+}`
+
+	lang, messages, err := FromARB([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, "fr", lang)
+	require.Len(t, messages, 3)
+
+	inbox := messages[0]
+	assert.Equal(t, "inbox", inbox.ID)
+	assert.Equal(t, "Inbox count", inbox.Description)
+	assert.Equal(t, "Vous avez aucun message.", inbox.Zero)
+	assert.Equal(t, "Vous avez {{.n}} message.", inbox.One)
+	assert.Equal(t, "Vous avez {{.n}} messages.", inbox.Other)
+
+	assert.Equal(t, "Total : {{.amount}}", messages[1].Other)
+	assert.Equal(t, "L'option {x}", messages[2].Other)
+
+	for _, invalid := range []string{
+		`{"a": "{g, select, male{he} other{they}}"}`,
+		`{"a": "{n, plural, one{x}}"}`,
+		`{"a": "unclosed {name"}`,
+		`{"a": 1}`,
+	} {
+		_, _, err := FromARB([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+
+	_, err = ToARB("en", []*i18n.Message{{ID: "A", Other: "{{if .X}}x{{end}}"}})
+	assert.Error(t, err)
+}
+
+func TestChromeRoundTrip(t *testing.T) {
+	data, err := ToChrome(testMessages)
+	require.NoError(t, err)
+
+	var raw map[string]chromeMessage
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "Hello, $Name$!", raw["WELCOME"].Message)
+	assert.Equal(t, "$1", raw["WELCOME"].Placeholders["Name"].Content)
+	assert.Equal(t, "$Count$ items in $Cart$", raw["ITEMS"].Message)
+	assert.Equal(t, "$2", raw["ITEMS"].Placeholders["Cart"].Content)
+	assert.Equal(t, "Costs $$5 {not a placeholder} isn't it", raw["PRICE"].Message)
+
+	messages, err := FromChrome(data)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	assert.Equal(t, testMessages[0], messages[0])
+	// 复数形式只保留 other
+	assert.Equal(t, &i18n.Message{ID: "ITEMS", Other: "{{.Count}} items in {{.Cart}}"}, messages[1])
+	assert.Equal(t, testMessages[2], messages[2])
+}
+
+func TestFromChrome(t *testing.T) {
+	data := `{
+  "greeting": {
+    "message": "Hi $USER$, you have $count$ new $1 from $site$",
+    "placeholders": {
+      "user": {"content": "$1"},
+      "Count": {"content": "$2", "example": "3"},
+      "site": {"content": "example.com"}
+    }
+  }
+}`
+	messages, err := FromChrome([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Hi {{.user}}, you have {{.Count}} new {{.Arg1}} from example.com", messages[0].Other)
+
+	_, err = FromChrome([]byte(`{"a": {"message": "$missing$"}}`))
+	assert.Error(t, err)
+
+	_, err = ToChrome([]*i18n.Message{{ID: "a.b", Other: "x"}, {ID: "a_b", Other: "y"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Package convert 在 go-i18n 消息与其他客户端使用的消息格式之间转换
+// 支持 Flutter ARB 和 Chrome 扩展的 messages.json，便于后端语言文件作为所有客户端的唯一来源
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// templateVarPattern 可转换的模板变量 {{.Name}}
+var templateVarPattern = regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// segment 模板中的一段文本或变量
+type segment struct {
+	text     string
+	variable string
+}
+
+// splitTemplate 将 go-i18n 模板拆分为文本和变量
+// 只支持 {{.Name}} 形式的变量，其他模板动作无法在目标格式中表达
+func splitTemplate(s string) ([]segment, error) {
+	var segments []segment
+	last := 0
+	for _, loc := range templateVarPattern.FindAllStringSubmatchIndex(s, -1) {
+		if loc[0] > last {
+			segments = append(segments, segment{text: s[last:loc[0]]})
+		}
+		segments = append(segments, segment{variable: s[loc[2]:loc[3]]})
+		last = loc[1]
+	}
+	if last < len(s) {
+		segments = append(segments, segment{text: s[last:]})
+	}
+
+	for _, seg := range segments {
+		if strings.Contains(seg.text, "{{") {
+			return nil, fmt.Errorf("unsupported template action in %q", s)
+		}
+	}
+	return segments, nil
+}
+
+// templateVariables 获取模板中使用的变量，按首次出现的顺序排列
+func templateVariables(s string) []string {
+	var vars []string
+	seen := make(map[string]bool)
+	for _, match := range templateVarPattern.FindAllStringSubmatch(s, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			vars = append(vars, match[1])
+		}
+	}
+	return vars
+}
+
+// templateVar 生成模板变量
+func templateVar(name string) string {
+	return "{{." + name + "}}"
+}
@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// FormatNumber 按上下文中的语言格式化数字，如 en: 1,234.5；de: 1.234,5；ar: ١٬٢٣٤٫٥
+// 参数不是数字时原样输出
+func FormatNumber(ctx context.Context, v interface{}) string {
+	s, err := internal.FormatNumber(languageTag(GetLanguageFromContext(ctx)), v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return s
+}
+
+// FormatCurrency 按上下文中的语言格式化金额，code 为 ISO 4217 货币代码（如 "EUR"、"CNY"）
+// 如 en: €1,234.50；de: 1.234,50 €；参数或货币代码无效时原样输出
+func FormatCurrency(ctx context.Context, v interface{}, code string) string {
+	s, err := internal.FormatCurrency(languageTag(GetLanguageFromContext(ctx)), v, code)
+	if err != nil {
+		return fmt.Sprintf("%v %s", v, code)
+	}
+	return s
+}
+
+// FormatPercent 按上下文中的语言格式化百分比，0.25 输出为 en: 25%；de: 25 %
+func FormatPercent(ctx context.Context, v interface{}) string {
+	s, err := internal.FormatPercent(languageTag(GetLanguageFromContext(ctx)), v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return s
+}
+
+// DateStyle 日期和时间的格式样式
+type DateStyle = internal.DateStyle
+
+const (
+	DateShort  = internal.DateShort  // en: 1/2/06
+	DateMedium = internal.DateMedium // en: Jan 2, 2006
+	DateLong   = internal.DateLong   // en: January 2, 2006
+	DateFull   = internal.DateFull   // en: Monday, January 2, 2006
+)
+
+// FormatDate 按上下文中的语言和时区格式化日期，如 zh-CN long: 2006年1月2日
+func FormatDate(ctx context.Context, t time.Time, style DateStyle) string {
+	return internal.FormatDate(languageTag(GetLanguageFromContext(ctx)), inTimeZone(ctx, t), style)
+}
+
+// FormatTime 按上下文中的语言和时区格式化时间，如 en short: 3:04 PM；de short: 15:04
+func FormatTime(ctx context.Context, t time.Time, style DateStyle) string {
+	return internal.FormatTime(languageTag(GetLanguageFromContext(ctx)), inTimeZone(ctx, t), style)
+}
+
+// FormatDateTime 按上下文中的语言和时区格式化日期和时间
+func FormatDateTime(ctx context.Context, t time.Time, style DateStyle) string {
+	return internal.FormatDateTime(languageTag(GetLanguageFromContext(ctx)), inTimeZone(ctx, t), style)
+}
+
+// FormatRelative 按上下文中的语言格式化相对当前的时间，如 "3 minutes ago"、"3分钟前"、"in 2 days"
+func FormatRelative(ctx context.Context, t time.Time) string {
+	return internal.FormatRelative(languageTag(GetLanguageFromContext(ctx)), t, time.Now())
+}
+
+// ListStyle 列表的连接方式
+type ListStyle = internal.ListStyle
+
+const (
+	ListAnd = internal.ListAnd // A, B, and C
+	ListOr  = internal.ListOr  // A, B, or C
+)
+
+// FormatList 按上下文中的语言连接列表，如 en: "A, B, and C"；zh-CN: "A、B和C"
+func FormatList(ctx context.Context, items []string, style ListStyle) string {
+	return internal.FormatList(languageTag(GetLanguageFromContext(ctx)), items, style)
+}
+
+// FormatUnit 按上下文中的语言格式化带单位的数量，单位名称与 Pluralize 一样按 CLDR 复数规则选择，
+// 如 en-GB: "5 kilometres"；ru: "5 километров"；参数或单位无效时原样输出
+func FormatUnit(ctx context.Context, v interface{}, unit string) string {
+	s, err := internal.FormatUnit(languageTag(GetLanguageFromContext(ctx)), v, unit)
+	if err != nil {
+		return fmt.Sprintf("%v %s", v, unit)
+	}
+	return s
+}
+
+// FormatDuration 按上下文中的语言格式化时长，如 en: "2 hours 5 minutes"；zh-CN: "2小时5分钟"
+// maxUnits 大于 0 时只输出最大的几个单位，如 maxUnits 为 1 时 "2 hours"
+func FormatDuration(ctx context.Context, d time.Duration, maxUnits int) string {
+	return internal.FormatDuration(languageTag(GetLanguageFromContext(ctx)), d, maxUnits)
+}
+
+// inTimeZone 转换到上下文中的时区
+func inTimeZone(ctx context.Context, t time.Time) time.Time {
+	if loc := GetTimeZoneFromContext(ctx); loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// TemplateFuncs 消息模板中可用的格式化函数，按 lang 的格式输出：
+//
+//	{{num .Amount}}  {{currency .Price "EUR"}}  {{percent .Ratio}}
+//	{{date .At "long"}}  {{time .At "short"}}  {{datetime .At}}  {{relative .At}}
+//	{{list .Names}}  {{unit .Distance "kilometer"}}  {{duration .Elapsed}}
+//	{{isolate .Name}}
+//
+// 日期样式可省略（默认为 medium）。翻译时会自动使用请求语言和时区对应的函数，也可以用于自定义模板
+func TemplateFuncs(lang string) template.FuncMap {
+	return templateFuncs(lang, nil, nil)
+}
+
+// templateFuncs 创建模板函数，日期先转换到 loc；调用 relative 时将 *volatile 设为 true
+func templateFuncs(lang string, loc *time.Location, volatile *bool) template.FuncMap {
+	tag := languageTag(lang)
+	funcs := internal.NumberFuncs(tag)
+	for name, fn := range internal.DateFuncs(tag, loc, nil, volatile) {
+		funcs[name] = fn
+	}
+	for name, fn := range internal.UnitFuncs(tag) {
+		funcs[name] = fn
+	}
+	for name, fn := range internal.BidiFuncs() {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// languageTag 解析语言代码，无效时使用 language.Und（通用格式）
+func languageTag(lang string) language.Tag {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
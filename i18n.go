@@ -2,8 +2,9 @@ package i18n
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"sync"
 	"time"
@@ -22,14 +23,19 @@ var (
 
 // Service i18n 服务
 type Service struct {
-	bundle     *i18n.Bundle
-	translator Translator
-	cache      internal.CacheManager
-	pool       internal.PoolManager
-	config     Config
-	watcher    internal.FileWatcher
-	initTime   time.Time
-	mu         sync.RWMutex
+	bundle         *i18n.Bundle
+	translator     Translator
+	cache          internal.CacheManager
+	pool           internal.PoolManager
+	loader         *internal.LocaleLoader
+	fallbacks      *internal.FallbackChains
+	missing        *internal.MissingRegistry
+	overrides      *internal.OverrideStore
+	config         Config
+	watcher        internal.FileWatcher
+	sourceWatchers []io.Closer
+	initTime       time.Time
+	mu             sync.RWMutex
 }
 
 // Config 配置结构
@@ -39,6 +45,24 @@ type Config struct {
 	FallbackLanguage string `yaml:"fallback_language" json:"fallback_language"`
 	LocalesPath      string `yaml:"locales_path" json:"locales_path"`
 
+	// 各语言的降级链，如 {"zh-HK": ["zh-TW", "en"]}，未配置的语言按 CLDR 父语言推导（pt-BR -> pt），
+	// 降级链总是以 FallbackLanguage 结尾
+	FallbackChains map[string][]string `yaml:"fallback_chains,omitempty" json:"fallback_chains,omitempty"`
+
+	// MissingHandler 缺失翻译的处理函数（如上报到日志或监控），为空时只记录到 MissingTranslations
+	MissingHandler MissingHandler `yaml:"-" json:"-"`
+
+	// 缺失翻译记录的数量上限，默认为 10000
+	MissingLimit int `yaml:"missing_limit,omitempty" json:"missing_limit,omitempty"`
+
+	// FS 语言文件所在的文件系统（如 embed.FS、fstest.MapFS），LocalesPath 为其中的相对路径
+	// 为空时从操作系统文件系统加载
+	FS fs.FS `yaml:"-" json:"-"`
+
+	// MessageSources 在语言文件之后按顺序加载的其他消息来源（如 NewSQLSource、NewFileSource），
+	// 同一语言中的同名消息后加载的覆盖先加载的，Reload 时同样重新加载
+	MessageSources []MessageSource `yaml:"-" json:"-"`
+
 	// 语言文件配置
 	LocaleConfig LocaleConfig `yaml:"locale_config" json:"locale_config"`
 
@@ -51,6 +75,21 @@ type Config struct {
 	// 对象池配置
 	Pool PoolConfig `yaml:"pool" json:"pool"`
 
+	// 严格模式：翻译出错（消息不存在、模板执行失败、缺少复数形式）时 T、TPlural 等函数 panic，
+	// 而不是返回降级结果，适合在测试中使用；未设置 TemplateCheck 时按 "error" 检查模板
+	Strict bool `yaml:"strict" json:"strict"`
+
+	// 伪本地化：启用后 en-XA（重音、加长）和 ar-XB（从右到左）由默认语言的消息实时生成，
+	// 中间件也会接受这两个语言，便于测试发现硬编码的字符串和布局问题
+	PseudoLocalization bool `yaml:"pseudo_localization" json:"pseudo_localization"`
+
+	// 加载时模板检查："off"（默认）、"warn" 或 "error"。加载语言文件后编译所有语言中的消息模板，
+	// 检查模板能否解析以及占位符是否与默认语言一致；"warn" 记录日志，"error" 使 NewService 和 Reload 返回 *TemplateCheckError
+	TemplateCheck string `yaml:"template_check,omitempty" json:"template_check,omitempty"`
+
+	// 运行时消息覆盖（见 Service.SetOverride）的持久化文件，为空时覆盖只保存在内存中
+	OverridesFile string `yaml:"overrides_file,omitempty" json:"overrides_file,omitempty"`
+
 	// 调试和监控
 	Debug         bool `yaml:"debug" json:"debug"`
 	EnableMetrics bool `yaml:"enable_metrics" json:"enable_metrics"`
@@ -76,6 +115,20 @@ type LocaleConfig struct {
 
 	// 模块列表（仅在嵌套模式下使用）
 	Modules []string `yaml:"modules,omitempty" json:"modules,omitempty"`
+
+	// 树形语言文件（如 {"user": {"profile": {"title": "..."}}}）展开为消息ID时使用的分隔符，默认为 "."
+	KeySeparator string `yaml:"key_separator,omitempty" json:"key_separator,omitempty"`
+
+	// 启用模块命名空间（仅在嵌套模式下生效）：消息以 "errors:NOT_FOUND" 或 "errors.NOT_FOUND" 访问，
+	// 不同模块的同名消息不再互相覆盖
+	Namespaces bool `yaml:"namespaces" json:"namespaces"`
+
+	// 未指定命名空间且上下文中没有命名空间时使用的默认命名空间
+	DefaultNamespace string `yaml:"default_namespace,omitempty" json:"default_namespace,omitempty"`
+
+	// 消息语法: "template"（默认，Go 模板）或 "icu"（ICU MessageFormat，支持 plural、select、selectordinal），
+	// 使用 ICU 时加载语言文件会检查消息语法
+	MessageFormat string `yaml:"message_format,omitempty" json:"message_format,omitempty"`
 }
 
 // ResponseConfig 响应码配置
@@ -158,13 +211,14 @@ func NewService(config Config) (*Service, error) {
 	}
 
 	// 创建 bundle
-	bundle := i18n.NewBundle(language.English)
-	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	bundle := newBundle()
 
 	service := &Service{
-		bundle:   bundle,
-		config:   config,
-		initTime: time.Now(),
+		bundle:    bundle,
+		loader:    newLocaleLoader(config, config.LocalesPath, bundle),
+		fallbacks: internal.NewFallbackChains(config.FallbackChains, config.FallbackLanguage),
+		config:    config,
+		initTime:  time.Now(),
 	}
 
 	// 创建缓存管理器
@@ -184,22 +238,42 @@ func NewService(config Config) (*Service, error) {
 			WarmUp:           config.Pool.WarmUp,
 			Languages:        config.Pool.Languages,
 			FallbackLanguage: config.FallbackLanguage,
+			FallbackChains:   config.FallbackChains,
 		}, bundle)
 	}
 
+	// 加载运行时消息覆盖
+	overrides, err := internal.NewOverrideStore(config.OverridesFile)
+	if err != nil {
+		return nil, err
+	}
+	service.overrides = overrides
+
 	// 创建翻译器
-	service.translator = NewTranslator(bundle, service.cache, service.pool, config)
+	translator := newTranslator(bundle, service.cache, service.pool, config, service.loader)
+	translator.overrides = overrides
+	service.translator = translator
+	service.missing = translator.missing
 
-	// 创建文件监听器
-	if config.EnableWatcher {
+	// 创建文件监听器（嵌入式文件系统不会变化，无需监听）
+	if config.EnableWatcher && config.FS == nil {
 		service.watcher = internal.NewFileWatcher(config.LocalesPath, service.reloadLocales)
 	}
 
 	// 加载语言文件
 	if err := service.loadLocales(); err != nil {
+		service.Close()
 		return nil, err
 	}
 
+	// 监听其他消息来源的变化
+	if config.EnableWatcher {
+		if err := service.watchSources(); err != nil {
+			service.Close()
+			return nil, err
+		}
+	}
+
 	// 预热对象池
 	if config.Pool.WarmUp && service.pool != nil {
 		service.pool.WarmUp(config.Pool.Languages)
@@ -211,6 +285,13 @@ func NewService(config Config) (*Service, error) {
 	return service, nil
 }
 
+// newBundle 创建注册了所有语言文件格式的 bundle
+func newBundle() *i18n.Bundle {
+	bundle := i18n.NewBundle(language.English)
+	internal.RegisterUnmarshalFuncs(bundle)
+	return bundle
+}
+
 // GetService 获取全局服务实例
 func GetService() *Service {
 	if globalInstance == nil {
@@ -233,13 +314,75 @@ func (s *Service) Translate(ctx context.Context, messageID string, templateData
 
 // TranslateFromGin 从 Gin Context 翻译
 func (s *Service) TranslateFromGin(c *gin.Context, messageID string, templateData ...map[string]interface{}) string {
+	return s.translator.Translate(s.ginContext(c), messageID, templateData...)
+}
+
+// Plural 复数翻译，按 count 和当前语言的复数规则选择消息的 one/few/other 等形式
+func (s *Service) Plural(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string {
+	return s.translator.Pluralize(ctx, messageID, count, templateData...)
+}
+
+// PluralFromGin 从 Gin Context 进行复数翻译
+func (s *Service) PluralFromGin(c *gin.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string {
+	return s.translator.Pluralize(s.ginContext(c), messageID, count, templateData...)
+}
+
+// TranslateE 翻译并返回翻译错误（*TranslationError），出错时仍然返回降级结果，不受严格模式影响
+func (s *Service) TranslateE(ctx context.Context, messageID string, templateData ...map[string]interface{}) (string, error) {
+	return s.translator.TranslateE(ctx, messageID, templateData...)
+}
+
+// TranslateFromGinE 从 Gin Context 翻译并返回翻译错误
+func (s *Service) TranslateFromGinE(c *gin.Context, messageID string, templateData ...map[string]interface{}) (string, error) {
+	return s.translator.TranslateE(s.ginContext(c), messageID, templateData...)
+}
+
+// PluralE 复数翻译并返回翻译错误
+func (s *Service) PluralE(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) (string, error) {
+	return s.translator.PluralizeE(ctx, messageID, count, templateData...)
+}
+
+// TranslateTemplate 按当前语言渲染模板字符串（不查找语言文件）
+func (s *Service) TranslateTemplate(ctx context.Context, template string, templateData ...map[string]interface{}) string {
+	return s.translator.TranslateTemplate(ctx, template, templateData...)
+}
+
+// FallbackChain 返回语言本身及其降级链，如 zh-HK -> zh-TW -> en、pt-BR -> pt -> en
+func (s *Service) FallbackChain(lang string) []string {
+	return s.fallbacks.Chain(lang)
+}
+
+// MissingTranslations 返回运行期间记录的缺失翻译（按语言和消息ID去重），可用于导出未翻译的消息
+// 消息只能从请求语言的降级链之外取得（如 ko 请求使用了 en 的翻译）或完全找不到时视为缺失
+func (s *Service) MissingTranslations() []MissingRecord {
+	return s.missing.Records()
+}
+
+// ResetMissingTranslations 清空缺失翻译记录
+func (s *Service) ResetMissingTranslations() {
+	s.missing.Reset()
+}
+
+// ginContext 使用 Gin Context 中的语言、命名空间、时区和消息变体构建翻译上下文
+func (s *Service) ginContext(c *gin.Context) context.Context {
 	lang, _ := c.Get("i18n_language")
 	if lang == nil {
 		lang = s.config.DefaultLanguage
 	}
 
 	ctx := context.WithValue(context.Background(), LanguageKey, lang)
-	return s.translator.Translate(ctx, messageID, templateData...)
+	if namespace := c.GetString(string(NamespaceKey)); namespace != "" {
+		ctx = SetNamespaceToContext(ctx, namespace)
+	}
+	if value, exists := c.Get(string(TimeZoneKey)); exists {
+		if loc, ok := value.(*time.Location); ok {
+			ctx = SetTimeZoneToContext(ctx, loc)
+		}
+	}
+	if variants := c.GetStringSlice(string(VariantKey)); len(variants) > 0 {
+		ctx = SetVariantsToContext(ctx, variants...)
+	}
+	return ctx
 }
 
 // GetLanguage 获取当前语言
@@ -281,6 +424,16 @@ func (s *Service) GetStats() internal.Stats {
 	}
 }
 
+// GetLocaleStats 获取已加载语言文件的统计信息
+func (s *Service) GetLocaleStats() internal.LocaleFileStats {
+	return s.localeLoader().GetStats()
+}
+
+// ValidateLocaleStructure 验证磁盘上的语言文件结构是否与配置一致
+func (s *Service) ValidateLocaleStructure() error {
+	return s.localeLoader().ValidateLocaleStructure()
+}
+
 // GetMetrics 获取性能指标
 func (s *Service) GetMetrics() internal.Metrics {
 	return internal.Metrics{
@@ -311,6 +464,13 @@ func (s *Service) Close() error {
 		}
 	}
 
+	// 停止监听其他消息来源
+	for _, w := range s.sourceWatchers {
+		if err := w.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// 关闭缓存
 	if s.cache != nil {
 		if err := s.cache.Close(); err != nil {
@@ -344,6 +504,36 @@ func TFromGin(c *gin.Context, messageID string, templateData ...map[string]inter
 	return GetService().TranslateFromGin(c, messageID, templateData...)
 }
 
+// TPlural 复数翻译（便捷方法）
+func TPlural(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string {
+	return GetService().Plural(ctx, messageID, count, templateData...)
+}
+
+// TPluralFromGin 从 Gin Context 进行复数翻译
+func TPluralFromGin(c *gin.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string {
+	return GetService().PluralFromGin(c, messageID, count, templateData...)
+}
+
+// TTemplate 按当前语言渲染模板字符串（便捷方法）
+func TTemplate(ctx context.Context, template string, templateData ...map[string]interface{}) string {
+	return GetService().TranslateTemplate(ctx, template, templateData...)
+}
+
+// TE 翻译并返回翻译错误，可以用 errors.Is(err, ErrMessageNotFound) 等判断错误类型
+func TE(ctx context.Context, messageID string, templateData ...map[string]interface{}) (string, error) {
+	return GetService().TranslateE(ctx, messageID, templateData...)
+}
+
+// TFromGinE 从 Gin Context 翻译并返回翻译错误
+func TFromGinE(c *gin.Context, messageID string, templateData ...map[string]interface{}) (string, error) {
+	return GetService().TranslateFromGinE(c, messageID, templateData...)
+}
+
+// TPluralE 复数翻译并返回翻译错误
+func TPluralE(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) (string, error) {
+	return GetService().PluralE(ctx, messageID, count, templateData...)
+}
+
 // GetLanguage 获取当前语言
 func GetLanguage(ctx context.Context) string {
 	return GetService().GetLanguage(ctx)
@@ -354,11 +544,21 @@ func GetLanguageFromGin(c *gin.Context) string {
 	return GetService().GetLanguageFromGin(c)
 }
 
+// MissingTranslations 返回运行期间记录的缺失翻译
+func MissingTranslations() []MissingRecord {
+	return GetService().MissingTranslations()
+}
+
 // GetStats 获取统计信息
 func GetStats() internal.Stats {
 	return GetService().GetStats()
 }
 
+// GetLocaleStats 获取已加载语言文件的统计信息
+func GetLocaleStats() internal.LocaleFileStats {
+	return GetService().GetLocaleStats()
+}
+
 // GetMetrics 获取性能指标
 func GetMetrics() internal.Metrics {
 	return GetService().GetMetrics()
@@ -380,15 +580,28 @@ func Close() error {
 // 辅助方法
 
 func (s *Service) supportedLanguages() []string {
-	return []string{"en", "zh-CN", "zh-TW"}
+	return s.localeLoader().Languages()
 }
 
 // 内部方法
 
+// localeLoader 返回当前使用的语言文件加载器，重新加载时会被替换
+func (s *Service) localeLoader() *internal.LocaleLoader {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loader
+}
+
 func (s *Service) loadLocales() error {
-	return s.translator.LoadLocales(s.config.LocalesPath)
+	if err := s.loader.LoadLocales(); err != nil {
+		return err
+	}
+	return s.checkTemplates(s.loader)
 }
 
+// reloadLocales 将语言文件和其他消息来源加载到新的 bundle，成功后替换正在使用的 bundle，
+// 因此翻译不会读到加载到一半的消息，已删除的消息也不再可用；
+// 加载失败或模板检查（TemplateCheck 为 "error"）失败时继续使用原来的消息
 func (s *Service) reloadLocales() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -397,13 +610,22 @@ func (s *Service) reloadLocales() error {
 		log.Printf("[i18n] Reloading locales from %s", s.config.LocalesPath)
 	}
 
-	// 重新加载语言文件
-	if err := s.loadLocales(); err != nil {
+	bundle := newBundle()
+	loader := newLocaleLoader(s.config, s.config.LocalesPath, bundle)
+	if err := loader.LoadLocales(); err != nil {
 		return err
 	}
 
-	// 清空缓存
-	if s.cache != nil {
+	if err := s.checkTemplates(loader); err != nil {
+		return err
+	}
+
+	// 替换 bundle，同时清空对象池和缓存
+	s.bundle = bundle
+	s.loader = loader
+	if t, ok := s.translator.(*translator); ok {
+		t.setLocales(bundle, loader)
+	} else if s.cache != nil {
 		s.cache.Clear()
 	}
 
@@ -2,14 +2,21 @@ package i18n
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
 
 	)
 
@@ -231,4 +238,516 @@ func TestIntegration(t *testing.T) {
 	// 清理
 	err = Close()
 	require.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func TestNewServiceWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":           {Data: []byte(`[{"id": "WELCOME", "translation": "Welcome"}]`)},
+		"locales/zh-CN/common.json": {Data: []byte(`[{"id": "WELCOME", "translation": "欢迎"}]`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocalesPath = "./locales"
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+	config.EnableWatcher = true
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	ctx := SetLanguageToContext(context.Background(), "zh-CN")
+	assert.Equal(t, "欢迎", service.Translate(ctx, "WELCOME"))
+	assert.Equal(t, 2, service.GetLocaleStats().TotalFiles)
+
+	// 重新加载同样从 fs.FS 读取
+	fsys["locales/zh-CN/common.json"] = &fstest.MapFile{Data: []byte(`[{"id": "WELCOME", "translation": "你好"}]`)}
+	require.NoError(t, service.Reload())
+	assert.Equal(t, "你好", service.Translate(ctx, "WELCOME"))
+}
+
+func TestNewServiceWithTreeLocaleFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.yaml": {Data: []byte("user:\n  profile:\n    title: Profile\n")},
+		"locales/de.json": {Data: []byte(`{"user": {"profile": {"title": "Profil"}}}`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{KeySeparator: "_"}
+	config.Pool.WarmUp = false
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	ctx := SetLanguageToContext(context.Background(), "de")
+	assert.Equal(t, "Profil", service.Translate(ctx, "user_profile_title"))
+}
+
+func TestServiceNamespaces(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en/common.json": {Data: []byte(`[{"id": "WELCOME", "translation": "Welcome"}, {"id": "OK", "translation": "OK"}]`)},
+		"locales/en/ui.json":     {Data: []byte(`[{"id": "WELCOME", "translation": "Welcome to the dashboard"}]`)},
+		"locales/en/errors.json": {Data: []byte(`[{"id": "NOT_FOUND", "translation": "Not found"}]`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{Mode: "nested", Namespaces: true, DefaultNamespace: "common"}
+	config.Pool.WarmUp = false
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	ctx := SetLanguageToContext(context.Background(), "en")
+	assert.Equal(t, "Welcome", service.Translate(ctx, "common:WELCOME"))
+	assert.Equal(t, "Welcome to the dashboard", service.Translate(ctx, "ui.WELCOME"))
+	assert.Equal(t, "Welcome", service.Translate(ctx, "WELCOME"))
+	assert.Equal(t, "Not found", service.Translate(ctx, "NOT_FOUND"))
+
+	uiCtx := SetNamespaceToContext(ctx, "ui")
+	assert.Equal(t, "Welcome to the dashboard", service.Translate(uiCtx, "WELCOME"))
+	// 上下文命名空间中不存在时回退到默认命名空间
+	assert.Equal(t, "OK", service.Translate(uiCtx, "OK"))
+	assert.Equal(t, "MISSING", service.Translate(ctx, "ui:MISSING"))
+
+	duplicates := service.GetLocaleStats().Duplicates
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, "WELCOME", duplicates[0].ID)
+	assert.Len(t, duplicates[0].Files, 2)
+
+	// Gin 请求可以设置默认命名空间
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("i18n_language", "en")
+	SetNamespaceToGin(c, "ui")
+	assert.Equal(t, "Welcome to the dashboard", service.TranslateFromGin(c, "WELCOME"))
+}
+
+func TestServiceICUMessageFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{
+  "FILES": "{count, plural, =0 {No files} one {# file} other {# files}}",
+  "REPLIED": "{gender, select, female {She} male {He} other {They}} replied to {name}"
+}`)},
+		"locales/ru.json": {Data: []byte(`{"FILES": "{count, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}"}`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{MessageFormat: "icu"}
+	config.Pool.WarmUp = false
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	ctx := SetLanguageToContext(context.Background(), "en")
+	assert.Equal(t, "No files", service.Translate(ctx, "FILES", map[string]interface{}{"count": 0}))
+	assert.Equal(t, "1 file", service.Translate(ctx, "FILES", map[string]interface{}{"count": 1}))
+	assert.Equal(t, "2 files", service.Plural(ctx, "FILES", 2))
+	assert.Equal(t, "She replied to Bob", service.Translate(ctx, "REPLIED", map[string]interface{}{"gender": "female", "name": "Bob"}))
+
+	ruCtx := SetLanguageToContext(context.Background(), "ru")
+	assert.Equal(t, "5 файлов", service.Translate(ruCtx, "FILES", map[string]interface{}{"count": 5}))
+	// 缺少的消息回退到降级语言，并按其复数规则格式化
+	assert.Equal(t, "They replied to Ann", service.Translate(ruCtx, "REPLIED", map[string]interface{}{"name": "Ann"}))
+}
+
+func TestServicePlural(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"ITEMS": {"one": "{{.Count}} item", "other": "{{.Count}} items"}}`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	ctx := SetLanguageToContext(context.Background(), "en")
+	assert.Equal(t, "1 item", service.Plural(ctx, "ITEMS", 1, map[string]interface{}{"Count": 1}))
+	assert.Equal(t, "2 items", service.Plural(ctx, "ITEMS", 2, map[string]interface{}{"Count": 2}))
+	assert.Equal(t, "Hi Ann", service.TranslateTemplate(ctx, "Hi {{.Name}}", map[string]interface{}{"Name": "Ann"}))
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("i18n_language", "en")
+	assert.Equal(t, "1 item", service.PluralFromGin(c, "ITEMS", 1, map[string]interface{}{"Count": 1}))
+}
+
+func TestMiddlewareFallbackChains(t *testing.T) {
+	service, err := NewService(Config{
+		DefaultLanguage:  "en",
+		FallbackLanguage: "en",
+		FallbackChains:   map[string][]string{"zh-HK": {"zh-TW"}},
+		LocalesPath:      t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	previous := globalInstance
+	globalInstance = service
+	defer func() { globalInstance = previous }()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(MiddlewareWithOpts(MiddlewareOptions{
+		HeaderKey:      "X-Language",
+		SupportedLangs: []string{"en", "pt", "zh-TW"},
+	}))
+	r.GET("/test", func(c *gin.Context) {
+		c.String(http.StatusOK, GetLanguageFromGin(c))
+	})
+
+	for _, tt := range []struct {
+		header, value, want string
+	}{
+		{"X-Language", "zh-HK", "zh-tw"},
+		{"X-Language", "pt-BR", "pt"},
+		{"Accept-Language", "zh-HK,en;q=0.5", "zh-TW"},
+		{"Accept-Language", "pt-BR,en;q=0.5", "pt"},
+		{"Accept-Language", "fr,en;q=0.5", "en"},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set(tt.header, tt.value)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Body.String(), tt.value)
+	}
+
+	assert.Equal(t, []string{"zh-HK", "zh-TW", "en"}, service.FallbackChain("zh-HK"))
+}
+
+func TestMiddlewarePseudoLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for enabled, want := range map[bool]string{true: "en-xa", false: "en"} {
+		service, err := NewService(Config{
+			DefaultLanguage:    "en",
+			FallbackLanguage:   "en",
+			PseudoLocalization: enabled,
+			LocalesPath:        t.TempDir(),
+		})
+		require.NoError(t, err)
+
+		previous := globalInstance
+		globalInstance = service
+
+		r := gin.New()
+		r.Use(Middleware())
+		r.GET("/test", func(c *gin.Context) {
+			c.String(http.StatusOK, GetLanguageFromGin(c))
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("X-Language", "en-XA")
+		r.ServeHTTP(w, req)
+		globalInstance = previous
+
+		assert.Equal(t, want, w.Body.String())
+	}
+}
+
+func TestMiddlewareDirection(t *testing.T) {
+	service, err := NewService(Config{
+		DefaultLanguage:  "en",
+		FallbackLanguage: "en",
+		LocalesPath:      t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	previous := globalInstance
+	globalInstance = service
+	defer func() { globalInstance = previous }()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(MiddlewareWithOpts(MiddlewareOptions{
+		HeaderKey:      "X-Language",
+		SupportedLangs: []string{"en", "ar", "he"},
+	}))
+	r.GET("/test", func(c *gin.Context) {
+		JSON(c, Success, GetDirectionFromGin(c))
+	})
+
+	for lang, want := range map[string]Direction{"ar": RightToLeft, "he": RightToLeft, "en": LeftToRight} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("X-Language", lang)
+		r.ServeHTTP(w, req)
+
+		var response Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, string(want), response.Data, lang)
+		assert.Equal(t, want, response.Meta.Dir, lang)
+	}
+
+	ctx := SetLanguageToContext(context.Background(), "ar")
+	assert.Equal(t, RightToLeft, GetDirection(ctx))
+	assert.Equal(t, "\u2068Ann\u2069", TTemplate(ctx, "{{isolate .Name}}", map[string]interface{}{"Name": "Ann"}))
+}
+
+func TestServiceMessageMetadata(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"WELCOME": {"other": "Welcome, {{.Name}}", "description": "Home title", "max_length": 10}}`)},
+		"locales/de.json": {Data: []byte(`{"WELCOME": "Willkommen, {{.Nmae}}"}`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	// 元数据不影响翻译
+	ctx := SetLanguageToContext(context.Background(), "en")
+	assert.Equal(t, "Welcome, Ann", service.Translate(ctx, "WELCOME", map[string]interface{}{"Name": "Ann"}))
+
+	info, ok := service.MessageInfo("WELCOME")
+	require.True(t, ok)
+	assert.Equal(t, "Home title", info.Description)
+	assert.Equal(t, []string{"Name"}, info.Placeholders)
+	assert.Len(t, service.MessageInfos(), 1)
+
+	issues := service.ValidateMessages()
+	require.Len(t, issues, 2)
+	assert.Equal(t, IssueMaxLength, issues[0].Kind)
+	assert.Equal(t, "de", issues[0].Lang)
+	assert.Equal(t, IssuePlaceholder, issues[1].Kind)
+	assert.EqualError(t, issues[1], "WELCOME (de): placeholder Nmae is not declared in the source language")
+}
+
+func TestServiceTemplateCheck(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"WELCOME": "Welcome, {{.Name}}", "TOTAL": "{{num .Count}} items"}`)},
+		"locales/de.json": {Data: []byte(`{"WELCOME": "Willkommen, {{.Nmae}}", "TOTAL": "{{num .Count}} Elemente"}`)},
+	}
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+	config.TemplateCheck = "error"
+
+	_, err := NewService(config)
+	var checkErr *TemplateCheckError
+	require.ErrorAs(t, err, &checkErr)
+	require.Len(t, checkErr.Issues, 1)
+	assert.Equal(t, IssuePlaceholderMismatch, checkErr.Issues[0].Kind)
+	assert.Equal(t, "WELCOME", checkErr.Issues[0].ID)
+
+	// warn 只记录日志
+	config.TemplateCheck = "warn"
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+	assert.Len(t, service.CheckTemplates(), 1)
+
+	config.TemplateCheck = "fail"
+	assert.Error(t, ValidateConfig(config))
+
+	// 严格模式下默认按 error 检查，显式设置的 TemplateCheck 优先
+	config.Strict = true
+	config.TemplateCheck = ""
+	_, err = NewService(config)
+	require.ErrorAs(t, err, &checkErr)
+	config.TemplateCheck = "off"
+	strict, err := NewService(config)
+	require.NoError(t, err)
+	strict.Close()
+	config.Strict = false
+
+	// 重新加载时同样检查，问题修复后恢复正常
+	service.config.TemplateCheck = "error"
+	fsys["locales/de.json"] = &fstest.MapFile{Data: []byte(`{"WELCOME": "Willkommen, {{.Name}", "TOTAL": "{{num .Count}} Elemente"}`)}
+	err = service.Reload()
+	require.ErrorAs(t, err, &checkErr)
+	assert.Equal(t, IssueTemplate, checkErr.Issues[0].Kind)
+
+	// 检查失败时继续使用原来的消息
+	assert.Equal(t, "Willkommen, Ann", service.Translate(SetLanguageToContext(context.Background(), "de"), "WELCOME", map[string]interface{}{"Nmae": "Ann"}))
+	issues := service.CheckTemplates()
+	require.Len(t, issues, 1)
+	assert.Equal(t, IssuePlaceholderMismatch, issues[0].Kind)
+
+	fsys["locales/de.json"] = &fstest.MapFile{Data: []byte(`{"WELCOME": "Willkommen, {{.Name}}", "TOTAL": "{{num .Count}} Elemente"}`)}
+	require.NoError(t, service.Reload())
+	assert.Empty(t, service.CheckTemplates())
+}
+
+func TestServiceOverrides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"WELCOME": "Welcome, {{.Name}}", "FILES": {"one": "{{.Count}} file", "other": "{{.Count}} files"}}`)},
+		"locales/de.json": {Data: []byte(`{"FILES": {"one": "{{.Count}} Datei", "other": "{{.Count}} Dateien"}}`)},
+	}
+	path := filepath.Join(t.TempDir(), "overrides.json")
+
+	config := DefaultConfig
+	config.FS = fsys
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+	config.OverridesFile = path
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	en := SetLanguageToContext(context.Background(), "en")
+	de := SetLanguageToContext(context.Background(), "de")
+	data := map[string]interface{}{"Name": "Ann"}
+	assert.Equal(t, "Welcome, Ann", service.Translate(en, "WELCOME", data))
+	assert.Equal(t, "Welcome, Ann", service.Translate(de, "WELCOME", data))
+
+	// 覆盖立即生效（包括已缓存的结果和降级到该语言的请求）
+	require.NoError(t, service.SetOverride("en", "WELCOME", "Hello, {{.Name}}!"))
+	assert.Equal(t, "Hello, Ann!", service.Translate(en, "WELCOME", data))
+	assert.Equal(t, "Hello, Ann!", service.Translate(de, "WELCOME", data))
+
+	require.NoError(t, service.SetOverride("de", "FILES", "{{.Count}} Datei(en)"))
+	assert.Equal(t, "1 Datei(en)", service.Plural(de, "FILES", 1, map[string]interface{}{"Count": 1}))
+	assert.Equal(t, "2 files", service.Plural(en, "FILES", 2, map[string]interface{}{"Count": 2}))
+
+	assert.Error(t, service.SetOverride("en", "WELCOME", "Hello, {{.Name"))
+	assert.Error(t, service.SetOverride("en", "WELCOME", "{{shout .Name}}"))
+
+	info, ok := service.MessageInfo("WELCOME")
+	require.True(t, ok)
+	assert.Equal(t, []string{"en"}, info.Languages)
+	assert.Equal(t, []string{"en"}, info.Overrides)
+
+	require.NoError(t, service.SetOverride("en", "NEW", "New"))
+	info, ok = service.MessageInfo("NEW")
+	require.True(t, ok)
+	assert.Empty(t, info.Languages)
+	assert.Len(t, service.MessageInfos(), 3)
+
+	// 重新加载语言文件不影响覆盖，重启后从文件恢复
+	require.NoError(t, service.Reload())
+	assert.Equal(t, "Hello, Ann!", service.Translate(en, "WELCOME", data))
+
+	restarted, err := NewService(config)
+	require.NoError(t, err)
+	defer restarted.Close()
+	assert.Equal(t, service.Overrides(), restarted.Overrides())
+
+	require.NoError(t, service.RemoveOverride("en", "WELCOME"))
+	require.NoError(t, service.RemoveOverride("en", "WELCOME"))
+	assert.Equal(t, "Welcome, Ann", service.Translate(en, "WELCOME", data))
+	assert.Len(t, service.Overrides(), 2)
+}
+
+func TestServiceSQLSource(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "i18n.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(fmt.Sprintf(SQLSchema, DefaultSQLTable))
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO i18n_messages (lang, id, other) VALUES ('en', 'WELCOME', 'Welcome from DB'), ('ja', 'WELCOME', 'ようこそ')`)
+	require.NoError(t, err)
+
+	config := DefaultConfig
+	config.FS = fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"WELCOME": "Welcome", "TITLE": "Title"}`)},
+	}
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+	config.MessageSources = []MessageSource{NewSQLSource(db, SQLSourceConfig{})}
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	en := SetLanguageToContext(context.Background(), "en")
+	ja := SetLanguageToContext(context.Background(), "ja")
+	assert.Equal(t, "Welcome from DB", service.Translate(en, "WELCOME"))
+	assert.Equal(t, "Title", service.Translate(en, "TITLE"))
+	assert.Equal(t, "ようこそ", service.Translate(ja, "WELCOME"))
+	assert.ElementsMatch(t, []string{"en", "ja"}, service.supportedLanguages())
+
+	// 管理后台修改后重新加载
+	_, err = db.Exec(`UPDATE i18n_messages SET other = 'Welcome back' WHERE lang = 'en' AND id = 'WELCOME'`)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome from DB", service.Translate(en, "WELCOME"))
+	require.NoError(t, service.Reload())
+	assert.Equal(t, "Welcome back", service.Translate(en, "WELCOME"))
+}
+
+// notifySource 在 onChange 返回（即重新加载完成）后发送通知的消息来源
+type notifySource struct {
+	MessageSource
+	reloaded chan struct{}
+}
+
+func (s notifySource) Watch(onChange func()) (io.Closer, error) {
+	return s.MessageSource.Watch(func() {
+		onChange()
+		s.reloaded <- struct{}{}
+	})
+}
+
+func TestServiceSQLSourceWatchRemovesDeletedMessages(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "i18n.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(fmt.Sprintf(SQLSchema, DefaultSQLTable))
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO i18n_messages (lang, id, other) VALUES ('en', 'WELCOME', 'Welcome'), ('en', 'PROMO', 'Sale today')`)
+	require.NoError(t, err)
+
+	source := notifySource{
+		MessageSource: NewSQLSource(db, SQLSourceConfig{PollInterval: 5 * time.Millisecond}),
+		reloaded:      make(chan struct{}, 1),
+	}
+	config := DefaultConfig
+	config.FS = fstest.MapFS{}
+	config.LocaleConfig = LocaleConfig{}
+	config.Pool.WarmUp = false
+	config.EnableWatcher = true
+	config.MessageSources = []MessageSource{source}
+
+	service, err := NewService(config)
+	require.NoError(t, err)
+	defer service.Close()
+
+	en := SetLanguageToContext(context.Background(), "en")
+	assert.Equal(t, "Sale today", service.Translate(en, "PROMO"))
+
+	// 重新加载期间翻译继续进行
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				service.Translate(en, "WELCOME")
+			}
+		}
+	}()
+
+	// 删除的行在检测到变化后不再可用（包括已缓存的翻译）
+	_, err = db.Exec(`DELETE FROM i18n_messages WHERE id = 'PROMO'`)
+	require.NoError(t, err)
+	select {
+	case <-source.reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("change was not detected")
+	}
+	close(done)
+	<-stopped
+	assert.Equal(t, "PROMO", service.Translate(en, "PROMO"))
+	assert.Equal(t, "Welcome", service.Translate(en, "WELCOME"))
+	_, ok := service.MessageInfo("PROMO")
+	assert.False(t, ok)
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"fmt"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+// Direction 文本方向，值与 HTML dir 属性一致
+type Direction string
+
+const (
+	LeftToRight Direction = "ltr"
+	RightToLeft Direction = "rtl"
+)
+
+const (
+	fsi = "\u2068" // FIRST STRONG ISOLATE
+	pdi = "\u2069" // POP DIRECTIONAL ISOLATE
+)
+
+// rtlScripts 从右到左书写的文字（ISO 15924）
+var rtlScripts = map[string]bool{
+	"Adlm": true, "Arab": true, "Hebr": true, "Mand": true, "Mend": true, "Nkoo": true,
+	"Rohg": true, "Samr": true, "Syrc": true, "Thaa": true, "Yezi": true,
+}
+
+// LanguageDirection 按语言的文字判断文本方向，未指定文字时使用最可能的文字（如 ar -> Arab、az -> Latn）
+func LanguageDirection(tag language.Tag) Direction {
+	script, _ := tag.Script()
+	if rtlScripts[script.String()] {
+		return RightToLeft
+	}
+	return LeftToRight
+}
+
+// Isolate 用 FSI ... PDI 包裹文本，文本的方向由其第一个强方向字符决定，不影响周围文本的排列
+// 用于在消息中插入方向未知的用户输入，如阿拉伯语消息中的英文用户名
+func Isolate(v interface{}) string {
+	return fsi + fmt.Sprint(v) + pdi
+}
+
+// BidiFuncs 双向文本的模板函数
+//
+//	{{isolate .UserName}}
+func BidiFuncs() template.FuncMap {
+	return template.FuncMap{
+		"isolate": Isolate,
+	}
+}
@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestLanguageDirection(t *testing.T) {
+	for lang, want := range map[string]Direction{
+		"ar": RightToLeft, "he": RightToLeft, "fa-IR": RightToLeft, "ur": RightToLeft, "ar-XB": RightToLeft,
+		"en": LeftToRight, "zh-CN": LeftToRight, "az": LeftToRight, "az-Arab": RightToLeft, "und": LeftToRight,
+	} {
+		assert.Equal(t, want, LanguageDirection(language.MustParse(lang)), lang)
+	}
+}
+
+func TestIsolate(t *testing.T) {
+	assert.Equal(t, "\u2068Ann\u2069", Isolate("Ann"))
+	assert.Equal(t, "\u206842\u2069", Isolate(42))
+}
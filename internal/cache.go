@@ -84,6 +84,21 @@ func (c *MemoryCache) Delete(key string) {
 	delete(c.items, key)
 }
 
+// DeleteFunc 删除所有匹配的缓存，返回删除的数量
+func (c *MemoryCache) DeleteFunc(match func(key string) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for key := range c.items {
+		if match(key) {
+			delete(c.items, key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
 // Clear 清空缓存
 func (c *MemoryCache) Clear() {
 	c.mu.Lock()
@@ -192,6 +207,10 @@ func (c *NoOpCache) Set(key, value string) {
 func (c *NoOpCache) Delete(key string) {
 }
 
+func (c *NoOpCache) DeleteFunc(match func(key string) bool) int {
+	return 0
+}
+
 func (c *NoOpCache) Clear() {
 }
 
@@ -203,15 +222,27 @@ func (c *NoOpCache) GetStats() CacheStats {
 	return CacheStats{}
 }
 
-// BuildCacheKey 构建缓存键的辅助函数
-func BuildCacheKey(lang, messageID string, templateData []map[string]interface{}) string {
+// 缓存键中复数计数段和模板数据段的前缀，与变体分隔符 ContextSeparator 和命名空间分隔符区分，
+// CacheKeyMatcher 据此判断消息ID在哪里结束
+const (
+	cacheKeyCount = "|count="
+	cacheKeyData  = "|data="
+)
+
+// BuildCacheKey 构建翻译结果缓存键："语言:消息ID[#变体][|count=类型=计数][|data=模板数据哈希]"，
+// 复数计数包含类型（1 和 1.0 可能匹配不同的复数形式）
+func BuildCacheKey(lang, messageID string, count interface{}, templateData []map[string]interface{}) string {
+	key := lang + ":" + messageID
+	if count != nil {
+		key += fmt.Sprintf("%s%T=%v", cacheKeyCount, count, count)
+	}
 	if len(templateData) == 0 {
-		return fmt.Sprintf("%s:%s", lang, messageID)
+		return key
 	}
 
 	// 对模板数据进行哈希处理
 	templateHash := md5.Sum([]byte(fmt.Sprintf("%v", templateData)))
-	return fmt.Sprintf("%s:%s:%x", lang, messageID, templateHash)
+	return fmt.Sprintf("%s%s%x", key, cacheKeyData, templateHash)
 }
 
 // CacheConfig 缓存配置（重新定义以避免循环依赖）
@@ -0,0 +1,449 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// DateStyle 日期和时间的格式样式
+type DateStyle string
+
+const (
+	DateShort  DateStyle = "short"  // 1/2/06、2006/1/2
+	DateMedium DateStyle = "medium" // Jan 2, 2006、2006年1月2日
+	DateLong   DateStyle = "long"   // January 2, 2006
+	DateFull   DateStyle = "full"   // Monday, January 2, 2006
+)
+
+// ParseDateStyle 解析样式名称，空字符串为 DateMedium
+func ParseDateStyle(s string) (DateStyle, error) {
+	switch style := DateStyle(strings.ToLower(s)); style {
+	case "":
+		return DateMedium, nil
+	case DateShort, DateMedium, DateLong, DateFull:
+		return style, nil
+	}
+	return "", fmt.Errorf("unsupported date style %q", s)
+}
+
+// FormatDate 按语言格式化日期，如 en long: January 2, 2006；zh-CN long: 2006年1月2日
+func FormatDate(tag language.Tag, t time.Time, style DateStyle) string {
+	l := dateLocaleOf(tag)
+	return l.format(t, l.date[styleIndex(style)])
+}
+
+// FormatTime 按语言格式化时间，如 en short: 3:04 PM；de short: 15:04
+func FormatTime(tag language.Tag, t time.Time, style DateStyle) string {
+	l := dateLocaleOf(tag)
+	return l.format(t, l.time[styleIndex(style)])
+}
+
+// FormatDateTime 按语言格式化日期和时间
+func FormatDateTime(tag language.Tag, t time.Time, style DateStyle) string {
+	l := dateLocaleOf(tag)
+	i := styleIndex(style)
+	return strings.NewReplacer("{1}", l.format(t, l.date[i]), "{0}", l.format(t, l.time[i])).Replace(l.dateTime)
+}
+
+// FormatRelative 按语言格式化 t 相对于 now 的时间，如 "3 minutes ago"、"in 2 days"、"3分钟前"
+// 选择最大的完整单位（秒、分钟、小时、天、周、月、年），不足一秒时输出 "now"
+func FormatRelative(tag language.Tag, t, now time.Time) string {
+	l := dateLocaleOf(tag)
+
+	d := t.Sub(now)
+	pattern := l.relFuture
+	if d < 0 {
+		d, pattern = -d, l.relPast
+	}
+
+	var unit, n int
+	switch {
+	case d < time.Second:
+		return l.now
+	case d < time.Minute:
+		unit, n = 0, int(d/time.Second)
+	case d < time.Hour:
+		unit, n = 1, int(d/time.Minute)
+	case d < 24*time.Hour:
+		unit, n = 2, int(d/time.Hour)
+	case d < 7*24*time.Hour:
+		unit, n = 3, int(d/(24*time.Hour))
+	case d < 30*24*time.Hour:
+		unit, n = 4, int(d/(7*24*time.Hour))
+	case d < 365*24*time.Hour:
+		unit, n = 5, int(d/(30*24*time.Hour))
+	default:
+		unit, n = 6, int(d/(365*24*time.Hour))
+	}
+
+	word := selectPluralForm(l.tag, l.units[unit], float64(n))
+	return strings.NewReplacer("{0}", strconv.Itoa(n), "{1}", word).Replace(pattern)
+}
+
+// DateFuncs 日期格式化的模板函数，loc 不为空时先转换到该时区；now 为空时使用 time.Now
+// 调用 relative 时会将 *volatile 设为 true（结果随时间变化，不应缓存），volatile 可以为空
+//
+//	{{date .CreatedAt "long"}}  {{time .CreatedAt "short"}}  {{datetime .CreatedAt}}  {{relative .CreatedAt}}
+func DateFuncs(tag language.Tag, loc *time.Location, now func() time.Time, volatile *bool) template.FuncMap {
+	if now == nil {
+		now = time.Now
+	}
+	in := func(t time.Time) time.Time {
+		if loc != nil {
+			return t.In(loc)
+		}
+		return t
+	}
+	styled := func(format func(language.Tag, time.Time, DateStyle) string) func(time.Time, ...string) (string, error) {
+		return func(t time.Time, style ...string) (string, error) {
+			s, err := ParseDateStyle(strings.Join(style, ""))
+			if err != nil {
+				return "", err
+			}
+			return format(tag, in(t), s), nil
+		}
+	}
+
+	return template.FuncMap{
+		"date":     styled(FormatDate),
+		"time":     styled(FormatTime),
+		"datetime": styled(FormatDateTime),
+		"relative": func(t time.Time) string {
+			if volatile != nil {
+				*volatile = true
+			}
+			return FormatRelative(tag, t, now())
+		},
+	}
+}
+
+func styleIndex(style DateStyle) int {
+	switch style {
+	case DateShort:
+		return 0
+	case DateLong:
+		return 2
+	case DateFull:
+		return 3
+	}
+	return 1
+}
+
+// dateLocale 语言的日期格式数据（取自 CLDR）
+// 模式使用 CLDR 语法：y 年、M/MM 月份、MMM/MMMM 月份名称、d/dd 日、EEE/EEEE 星期、H/HH/h 小时、mm 分、ss 秒、a 上下午、z 时区，'...' 为字面文本
+type dateLocale struct {
+	tag                    language.Tag
+	months, monthsAbbr     [12]string
+	weekdays, weekdaysAbbr [7]string // 从星期日开始
+	am, pm                 string
+	date, time             [4]string // short、medium、long、full
+	dateTime               string    // {1} 为日期，{0} 为时间
+
+	now                string
+	relPast, relFuture string      // {0} 为数量，{1} 为单位
+	units              [7][]string // 秒、分钟、小时、天、周、月、年；每个单位的复数形式顺序见 pluralFormOrder
+}
+
+// format 按 CLDR 模式格式化时间
+func (l *dateLocale) format(t time.Time, pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		if !strings.ContainsRune("yMdEHhmsaz", rune(c)) {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		i += n
+
+		switch c {
+		case 'y':
+			if n == 2 {
+				fmt.Fprintf(&b, "%02d", t.Year()%100)
+			} else {
+				b.WriteString(strconv.Itoa(t.Year()))
+			}
+		case 'M':
+			switch n {
+			case 1:
+				b.WriteString(strconv.Itoa(int(t.Month())))
+			case 2:
+				fmt.Fprintf(&b, "%02d", int(t.Month()))
+			case 3:
+				b.WriteString(l.monthsAbbr[t.Month()-1])
+			default:
+				b.WriteString(l.months[t.Month()-1])
+			}
+		case 'd':
+			fmt.Fprintf(&b, "%0*d", n, t.Day())
+		case 'E':
+			if n >= 4 {
+				b.WriteString(l.weekdays[t.Weekday()])
+			} else {
+				b.WriteString(l.weekdaysAbbr[t.Weekday()])
+			}
+		case 'H':
+			fmt.Fprintf(&b, "%0*d", n, t.Hour())
+		case 'h':
+			h := t.Hour() % 12
+			if h == 0 {
+				h = 12
+			}
+			fmt.Fprintf(&b, "%0*d", n, h)
+		case 'm':
+			fmt.Fprintf(&b, "%0*d", n, t.Minute())
+		case 's':
+			fmt.Fprintf(&b, "%0*d", n, t.Second())
+		case 'a':
+			if t.Hour() < 12 {
+				b.WriteString(l.am)
+			} else {
+				b.WriteString(l.pm)
+			}
+		case 'z':
+			b.WriteString(t.Format("MST"))
+		}
+	}
+	return b.String()
+}
+
+// dateLocaleOf 选择最接近的日期格式数据，没有对应数据的语言使用英语
+func dateLocaleOf(tag language.Tag) *dateLocale {
+	_, i, confidence := dateMatcher.Match(tag)
+	if confidence == language.No {
+		return dateLocales[0]
+	}
+	return dateLocales[i]
+}
+
+var dateMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(dateLocales))
+	for i, l := range dateLocales {
+		tags[i] = l.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// cjkMonths 中文和日语的月份名称
+var cjkMonths = [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"}
+
+var dateLocales = []*dateLocale{
+	{
+		tag:          language.English,
+		months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsAbbr:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		weekdaysAbbr: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:           "AM",
+		pm:           "PM",
+		date:         [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+		time:         [4]string{"h:mm a", "h:mm:ss a", "h:mm:ss a z", "h:mm:ss a z"},
+		dateTime:     "{1}, {0}",
+		now:          "now",
+		relPast:      "{0} {1} ago",
+		relFuture:    "in {0} {1}",
+		units: [7][]string{
+			{"second", "seconds"}, {"minute", "minutes"}, {"hour", "hours"}, {"day", "days"},
+			{"week", "weeks"}, {"month", "months"}, {"year", "years"},
+		},
+	},
+	{
+		tag:          language.SimplifiedChinese,
+		months:       cjkMonths,
+		monthsAbbr:   cjkMonths,
+		weekdays:     [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		weekdaysAbbr: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		am:           "上午",
+		pm:           "下午",
+		date:         [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日EEEE"},
+		time:         [4]string{"HH:mm", "HH:mm:ss", "z HH:mm:ss", "z HH:mm:ss"},
+		dateTime:     "{1} {0}",
+		now:          "现在",
+		relPast:      "{0}{1}前",
+		relFuture:    "{0}{1}后",
+		units:        [7][]string{{"秒钟"}, {"分钟"}, {"小时"}, {"天"}, {"周"}, {"个月"}, {"年"}},
+	},
+	{
+		tag:          language.TraditionalChinese,
+		months:       cjkMonths,
+		monthsAbbr:   cjkMonths,
+		weekdays:     [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		weekdaysAbbr: [7]string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		am:           "上午",
+		pm:           "下午",
+		date:         [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日 EEEE"},
+		time:         [4]string{"ah:mm", "ah:mm:ss", "ah:mm:ss [z]", "ah:mm:ss [z]"},
+		dateTime:     "{1} {0}",
+		now:          "現在",
+		relPast:      "{0} {1}前",
+		relFuture:    "{0} {1}後",
+		units:        [7][]string{{"秒"}, {"分鐘"}, {"小時"}, {"天"}, {"週"}, {"個月"}, {"年"}},
+	},
+	{
+		tag:          language.Japanese,
+		months:       cjkMonths,
+		monthsAbbr:   cjkMonths,
+		weekdays:     [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		weekdaysAbbr: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		am:           "午前",
+		pm:           "午後",
+		date:         [4]string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		time:         [4]string{"H:mm", "H:mm:ss", "H:mm:ss z", "H:mm:ss z"},
+		dateTime:     "{1} {0}",
+		now:          "今",
+		relPast:      "{0} {1}前",
+		relFuture:    "{0} {1}後",
+		units:        [7][]string{{"秒"}, {"分"}, {"時間"}, {"日"}, {"週間"}, {"か月"}, {"年"}},
+	},
+	{
+		tag:          language.Korean,
+		months:       [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		monthsAbbr:   [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		weekdays:     [7]string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		weekdaysAbbr: [7]string{"일", "월", "화", "수", "목", "금", "토"},
+		am:           "오전",
+		pm:           "오후",
+		date:         [4]string{"yy. M. d.", "y. M. d.", "y년 M월 d일", "y년 M월 d일 EEEE"},
+		time:         [4]string{"a h:mm", "a h:mm:ss", "a h:mm:ss z", "a h:mm:ss z"},
+		dateTime:     "{1} {0}",
+		now:          "지금",
+		relPast:      "{0}{1} 전",
+		relFuture:    "{0}{1} 후",
+		units:        [7][]string{{"초"}, {"분"}, {"시간"}, {"일"}, {"주"}, {"개월"}, {"년"}},
+	},
+	{
+		tag:          language.German,
+		months:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:     [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		weekdaysAbbr: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		am:           "AM",
+		pm:           "PM",
+		date:         [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		time:         [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTime:     "{1}, {0}",
+		now:          "jetzt",
+		relPast:      "vor {0} {1}",
+		relFuture:    "in {0} {1}",
+		units: [7][]string{
+			{"Sekunde", "Sekunden"}, {"Minute", "Minuten"}, {"Stunde", "Stunden"}, {"Tag", "Tagen"},
+			{"Woche", "Wochen"}, {"Monat", "Monaten"}, {"Jahr", "Jahren"},
+		},
+	},
+	{
+		tag:          language.French,
+		months:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:     [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		weekdaysAbbr: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		am:           "AM",
+		pm:           "PM",
+		date:         [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:         [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTime:     "{1} {0}",
+		now:          "maintenant",
+		relPast:      "il y a {0} {1}",
+		relFuture:    "dans {0} {1}",
+		units: [7][]string{
+			{"seconde", "secondes"}, {"minute", "minutes"}, {"heure", "heures"}, {"jour", "jours"},
+			{"semaine", "semaines"}, {"mois", "mois"}, {"an", "ans"},
+		},
+	},
+	{
+		tag:          language.Spanish,
+		months:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		weekdaysAbbr: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		am:           "a. m.",
+		pm:           "p. m.",
+		date:         [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		time:         [4]string{"H:mm", "H:mm:ss", "H:mm:ss z", "H:mm:ss z"},
+		dateTime:     "{1}, {0}",
+		now:          "ahora",
+		relPast:      "hace {0} {1}",
+		relFuture:    "dentro de {0} {1}",
+		units: [7][]string{
+			{"segundo", "segundos"}, {"minuto", "minutos"}, {"hora", "horas"}, {"día", "días"},
+			{"semana", "semanas"}, {"mes", "meses"}, {"año", "años"},
+		},
+	},
+	{
+		tag:          language.Russian,
+		months:       [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		monthsAbbr:   [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		weekdays:     [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		weekdaysAbbr: [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		am:           "AM",
+		pm:           "PM",
+		date:         [4]string{"dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		time:         [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTime:     "{1}, {0}",
+		now:          "сейчас",
+		relPast:      "{0} {1} назад",
+		relFuture:    "через {0} {1}",
+		units: [7][]string{
+			{"секунду", "секунды", "секунд", "секунды"}, {"минуту", "минуты", "минут", "минуты"},
+			{"час", "часа", "часов", "часа"}, {"день", "дня", "дней", "дня"},
+			{"неделю", "недели", "недель", "недели"}, {"месяц", "месяца", "месяцев", "месяца"},
+			{"год", "года", "лет", "года"},
+		},
+	},
+	{
+		tag:          language.Portuguese,
+		months:       [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthsAbbr:   [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		weekdays:     [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		weekdaysAbbr: [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		am:           "AM",
+		pm:           "PM",
+		date:         [4]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		time:         [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTime:     "{1} {0}",
+		now:          "agora",
+		relPast:      "há {0} {1}",
+		relFuture:    "em {0} {1}",
+		units: [7][]string{
+			{"segundo", "segundos"}, {"minuto", "minutos"}, {"hora", "horas"}, {"dia", "dias"},
+			{"semana", "semanas"}, {"mês", "meses"}, {"ano", "anos"},
+		},
+	},
+	{
+		tag:          language.Italian,
+		months:       [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthsAbbr:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:     [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		weekdaysAbbr: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		am:           "AM",
+		pm:           "PM",
+		date:         [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:         [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTime:     "{1}, {0}",
+		now:          "ora",
+		relPast:      "{0} {1} fa",
+		relFuture:    "tra {0} {1}",
+		units: [7][]string{
+			{"secondo", "secondi"}, {"minuto", "minuti"}, {"ora", "ore"}, {"giorno", "giorni"},
+			{"settimana", "settimane"}, {"mese", "mesi"}, {"anno", "anni"},
+		},
+	},
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestFormatDate(t *testing.T) {
+	at := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		lang  string
+		style DateStyle
+		date  string
+		time  string
+	}{
+		{"en", DateShort, "3/5/24", "2:07 PM"},
+		{"en", DateFull, "Tuesday, March 5, 2024", "2:07:09 PM UTC"},
+		{"zh-CN", DateMedium, "2024年3月5日", "14:07:09"},
+		{"zh-TW", DateFull, "2024年3月5日 星期二", "下午2:07:09 [UTC]"},
+		{"ja", DateLong, "2024年3月5日", "14:07:09 UTC"},
+		{"de", DateLong, "5. März 2024", "14:07:09 UTC"},
+		{"fr", DateFull, "mardi 5 mars 2024", "14:07:09 UTC"},
+		{"es", DateLong, "5 de marzo de 2024", "14:07:09 UTC"},
+		{"ru", DateMedium, "5 мар. 2024 г.", "14:07:09"},
+		{"th", DateMedium, "Mar 5, 2024", "2:07:09 PM"}, // 没有数据的语言使用英语
+	}
+
+	for _, tt := range tests {
+		tag := language.MustParse(tt.lang)
+		assert.Equal(t, tt.date, FormatDate(tag, at, tt.style), tt.lang)
+		assert.Equal(t, tt.time, FormatTime(tag, at, tt.style), tt.lang)
+	}
+
+	assert.Equal(t, "Mar 5, 2024, 2:07:09 PM", FormatDateTime(language.English, at, DateMedium))
+	assert.Equal(t, "05.03.24, 14:07", FormatDateTime(language.German, at, DateShort))
+
+	_, err := ParseDateStyle("tiny")
+	assert.Error(t, err)
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		lang string
+		d    time.Duration
+		want string
+	}{
+		{"en", -3 * time.Minute, "3 minutes ago"},
+		{"en", -time.Hour, "1 hour ago"},
+		{"en", 2 * 24 * time.Hour, "in 2 days"},
+		{"en", 0, "now"},
+		{"zh-CN", -3 * time.Minute, "3分钟前"},
+		{"zh-CN", 3 * time.Hour, "3小时后"},
+		{"ja", -14 * 24 * time.Hour, "2 週間前"},
+		{"de", -2 * 24 * time.Hour, "vor 2 Tagen"},
+		{"ru", -2 * time.Minute, "2 минуты назад"},
+		{"ru", -5 * time.Minute, "5 минут назад"},
+		{"ru", -21 * time.Minute, "21 минуту назад"},
+		{"fr", 400 * 24 * time.Hour, "dans 1 an"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatRelative(language.MustParse(tt.lang), now.Add(tt.d), now), "%s %v", tt.lang, tt.d)
+	}
+}
+
+func TestDateFuncs(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	var volatile bool
+
+	funcs := DateFuncs(language.SimplifiedChinese, shanghai, func() time.Time { return now }, &volatile)
+	tmpl := template.Must(template.New("").Funcs(funcs).Parse(`{{date .At "long"}} {{time .At "short"}}`))
+
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, map[string]interface{}{"At": time.Date(2024, time.March, 5, 20, 30, 0, 0, time.UTC)}))
+	assert.Equal(t, "2024年3月6日 04:30", b.String())
+	assert.False(t, volatile)
+
+	tmpl = template.Must(template.New("").Funcs(funcs).Parse(`{{relative .At}}`))
+	b.Reset()
+	require.NoError(t, tmpl.Execute(&b, map[string]interface{}{"At": now.Add(-3 * time.Minute)}))
+	assert.Equal(t, "3分钟前", b.String())
+	assert.True(t, volatile)
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// FallbackChains 解析各语言的降级链
+//
+// 配置了降级链的语言按配置查找，如 zh-HK -> zh-TW -> en；
+// 其余语言按 CLDR 父语言推导，如 pt-BR -> pt -> en、en-AU -> en-001 -> en。
+// 降级链总是以全局降级语言结尾
+type FallbackChains struct {
+	chains   map[string][]string // 标准化的语言标签 -> 配置的降级语言
+	fallback string
+	resolved sync.Map // 语言 -> []string
+}
+
+// NewFallbackChains 创建降级链解析器，chains 的键和值为语言代码，fallback 为全局降级语言
+func NewFallbackChains(chains map[string][]string, fallback string) *FallbackChains {
+	f := &FallbackChains{
+		chains:   make(map[string][]string, len(chains)),
+		fallback: fallback,
+	}
+	for lang, chain := range chains {
+		f.chains[canonicalLanguage(lang)] = chain
+	}
+	return f
+}
+
+// Chain 返回语言本身及其降级链
+func (f *FallbackChains) Chain(lang string) []string {
+	if cached, ok := f.resolved.Load(lang); ok {
+		return cached.([]string)
+	}
+
+	chain := dedupeLanguages(append(append([]string{lang}, f.Parents(lang)...), f.fallback))
+	f.resolved.Store(lang, chain)
+	return chain
+}
+
+// Parents 返回语言的降级语言（不含语言本身；除非显式配置，也不含全局降级语言）
+// 语言自身没有配置时，依次使用 CLDR 父语言，直到遇到配置了降级链的父语言
+func (f *FallbackChains) Parents(lang string) []string {
+	tag, err := language.Parse(lang)
+	if err != nil || lang == "" {
+		return nil
+	}
+
+	var parents []string
+	for t := tag; !t.IsRoot(); t = t.Parent() {
+		if t != tag {
+			parents = append(parents, t.String())
+		}
+		if chain, ok := f.chains[t.String()]; ok {
+			return dedupeLanguages(append(parents, chain...))
+		}
+	}
+	return parents
+}
+
+// canonicalLanguage 标准化语言代码，无效的代码原样返回
+func canonicalLanguage(lang string) string {
+	if tag, err := language.Parse(lang); err == nil {
+		return tag.String()
+	}
+	return lang
+}
+
+// dedupeLanguages 去掉空值和重复的语言（按标准化后的语言代码比较），保持顺序
+func dedupeLanguages(langs []string) []string {
+	seen := make(map[string]bool, len(langs))
+	result := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		key := canonicalLanguage(lang)
+		if lang == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, lang)
+	}
+	return result
+}
+
+// Inherits 检查 lang 是否使用 from 的翻译，即 from 为 lang 本身或其降级语言（不含全局降级语言）
+func (f *FallbackChains) Inherits(lang, from string) bool {
+	target := canonicalLanguage(from)
+	if canonicalLanguage(lang) == target {
+		return true
+	}
+	for _, parent := range f.Parents(lang) {
+		if canonicalLanguage(parent) == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallbackChains(t *testing.T) {
+	chains := NewFallbackChains(map[string][]string{
+		"zh-HK": {"zh-TW", "en"},
+		"zh-MO": {"zh-HK"},
+		"es":    {"pt"},
+	}, "en")
+
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{"zh-HK", []string{"zh-HK", "zh-TW", "en"}},
+		{"zh-hk", []string{"zh-hk", "zh-TW", "en"}},
+		{"zh-MO", []string{"zh-MO", "zh-HK", "en"}},
+		{"pt-BR", []string{"pt-BR", "pt", "en"}},
+		{"en-AU", []string{"en-AU", "en-001", "en"}},
+		{"es-MX", []string{"es-MX", "es-419", "es", "pt", "en"}}, // 父语言 es 配置了降级链
+		{"en", []string{"en"}},
+		{"not a language", []string{"not a language", "en"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, chains.Chain(tt.lang), tt.lang)
+	}
+
+	assert.Equal(t, []string{"es-419", "es", "pt"}, chains.Parents("es-MX"))
+	assert.Empty(t, chains.Parents("en"))
+}
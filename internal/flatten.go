@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// DefaultKeySeparator 树形语言文件展开为消息ID时的默认分隔符
+const DefaultKeySeparator = "."
+
+// messageFields 消息对象的字段，对象的所有键都属于这些字段时视为一条消息而不是子树
+var messageFields = map[string]bool{
+	"id":          true,
+	"description": true,
+	"hash":        true,
+	"leftdelim":   true,
+	"rightdelim":  true,
+	"translation": true,
+	"zero":        true,
+	"one":         true,
+	"two":         true,
+	"few":         true,
+	"many":        true,
+	"other":       true,
+}
+
+// translationFields 消息对象中至少需要包含的翻译字段
+var translationFields = []string{"translation", "zero", "one", "two", "few", "many", "other"}
+
+// flattenMessages 将树形结构的语言文件展开为消息，各层键用 separator 连接为消息ID
+//
+//	{"user": {"profile": {"title": "..."}}}          -> user.profile.title
+//	{"user": {"files": {"one": "...", "other": "..."}}} -> user.files（复数消息）
+//
+// 对象只有在所有键都是消息字段（one/other/description 等，值为字符串）或元数据字段时才视为消息，
+// 因此 {"user": {"id": "...", "name": "..."}} 会展开为 user.id 和 user.name
+// 消息的元数据（见 MessageMetadata）按下标与消息对应返回
+func flattenMessages(raw map[string]interface{}, separator string) ([]*i18n.Message, []*MessageMetadata, error) {
+	if separator == "" {
+		separator = DefaultKeySeparator
+	}
+
+	f := &flattener{separator: separator}
+	if err := f.flatten("", raw); err != nil {
+		return nil, nil, err
+	}
+	return f.messages, f.metadata, nil
+}
+
+// flattener 展开树形语言文件时收集的消息和元数据
+type flattener struct {
+	separator string
+	messages  []*i18n.Message
+	metadata  []*MessageMetadata
+}
+
+func (f *flattener) flatten(prefix string, tree map[string]interface{}) error {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		id := key
+		if prefix != "" {
+			id = prefix + f.separator + key
+		}
+
+		switch value := normalizeMap(tree[key]).(type) {
+		case string:
+			f.messages = append(f.messages, &i18n.Message{ID: id, Other: value})
+			f.metadata = append(f.metadata, nil)
+
+		case map[string]interface{}:
+			if !isMessageObject(value) {
+				if err := f.flatten(id, value); err != nil {
+					return err
+				}
+				continue
+			}
+
+			meta, err := extractMetadata(value)
+			if err != nil {
+				return fmt.Errorf("message %s: %w", id, err)
+			}
+			m, err := i18n.NewMessage(value)
+			if err != nil {
+				return fmt.Errorf("message %s: %w", id, err)
+			}
+			m.ID = id
+			f.messages = append(f.messages, m)
+			f.metadata = append(f.metadata, meta)
+
+		default:
+			return fmt.Errorf("message %s: unsupported value type %T", id, value)
+		}
+	}
+	return nil
+}
+
+// isMessageObject 判断对象是消息（叶子）还是子树
+func isMessageObject(value map[string]interface{}) bool {
+	if len(value) == 0 {
+		return false
+	}
+
+	for key, v := range value {
+		if metadataFields[strings.ToLower(key)] {
+			continue
+		}
+		if !messageFields[strings.ToLower(key)] {
+			return false
+		}
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+
+	for _, field := range translationFields {
+		for key := range value {
+			if strings.EqualFold(key, field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeMap 将 YAML 解析出的 map[interface{}]interface{} 转换为 map[string]interface{}
+func normalizeMap(v interface{}) interface{} {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return v
+	}
+
+	normalized := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		normalized[fmt.Sprint(key)] = value
+	}
+	return normalized
+}
+
+// unflattenMessages 按 separator 将消息ID还原为树形结构，是 flattenMessages 的逆操作
+// metadata 按下标与消息对应，可以为空；只有 other 形式且没有描述和元数据的消息写为字符串，其他消息写为消息对象
+func unflattenMessages(messages []*i18n.Message, metadata []*MessageMetadata, separator string) (map[string]interface{}, error) {
+	if separator == "" {
+		separator = DefaultKeySeparator
+	}
+
+	tree := make(map[string]interface{})
+	for i, m := range messages {
+		path := strings.Split(m.ID, separator)
+		node := tree
+		for _, key := range path[:len(path)-1] {
+			child, exists := node[key]
+			if !exists {
+				child = make(map[string]interface{})
+				node[key] = child
+			}
+			subtree, ok := child.(map[string]interface{})
+			if !ok || isMessageObject(subtree) {
+				return nil, fmt.Errorf("message %s conflicts with message %s", m.ID, strings.Join(path[:len(path)-1], separator))
+			}
+			node = subtree
+		}
+
+		leaf := path[len(path)-1]
+		if _, exists := node[leaf]; exists {
+			return nil, fmt.Errorf("message %s conflicts with another message or subtree", m.ID)
+		}
+		node[leaf] = messageValue(m, metadataAt(metadata, i))
+	}
+	return tree, nil
+}
+
+// messageValue 消息在树形文件中的值
+func messageValue(m *i18n.Message, meta *MessageMetadata) interface{} {
+	if m.Description == "" && meta == nil && !IsPluralMessage(m) {
+		return m.Other
+	}
+
+	value := make(map[string]interface{})
+	for key, s := range map[string]string{
+		"description": m.Description,
+		"zero":        m.Zero,
+		"one":         m.One,
+		"two":         m.Two,
+		"few":         m.Few,
+		"many":        m.Many,
+		"other":       m.Other,
+	} {
+		if s != "" {
+			value[key] = s
+		}
+	}
+	if meta != nil {
+		if meta.MaxLength > 0 {
+			value["max_length"] = meta.MaxLength
+		}
+		if meta.Placeholders != nil {
+			value["placeholders"] = meta.Placeholders
+		}
+		if meta.Owner != "" {
+			value["owner"] = meta.Owner
+		}
+	}
+	return value
+}
+
+// metadataAt 返回下标对应的元数据，metadata 为空或没有该下标时返回 nil
+func metadataAt(metadata []*MessageMetadata, i int) *MessageMetadata {
+	if i < len(metadata) {
+		return metadata[i]
+	}
+	return nil
+}
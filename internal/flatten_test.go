@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLocaleLoaderTreeFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{
+  "user": {
+    "profile": {"title": "Profile", "id": "User ID"},
+    "files": {"one": "{{.Count}} file", "other": "{{.Count}} files"},
+    "greeting": {"description": "Shown after login", "other": "Hi"}
+  },
+  "OK": "OK"
+}`,
+		"fr.yaml": "user:\n  profile:\n    title: Profil\n  files:\n    one: \"{{.Count}} fichier\"\n    other: \"{{.Count}} fichiers\"\n",
+		"de.toml": "[user.profile]\ntitle = \"Profil\"\n",
+		"es.json": `[{"id": "user.profile.title", "translation": "Perfil"}]`,
+	})
+
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, bundle)
+	require.NoError(t, loader.LoadLocales())
+
+	en := indexMessages(loader.Messages("en"))
+	assert.Len(t, en, 5)
+	assert.Equal(t, "User ID", en["user.profile.id"].Other)
+	assert.Equal(t, "{{.Count}} file", en["user.files"].One)
+	assert.Equal(t, "Shown after login", en["user.greeting"].Description)
+	assert.Equal(t, "OK", en["OK"].Other)
+
+	for lang, want := range map[string]string{"en": "Profile", "fr": "Profil", "de": "Profil", "es": "Perfil"} {
+		loc := i18n.NewLocalizer(bundle, lang)
+		msg, err := loc.Localize(&i18n.LocalizeConfig{MessageID: "user.profile.title"})
+		require.NoError(t, err)
+		assert.Equal(t, want, msg, lang)
+	}
+
+	loc := i18n.NewLocalizer(bundle, "fr")
+	msg, err := loc.Localize(&i18n.LocalizeConfig{
+		MessageID:    "user.files",
+		PluralCount:  2,
+		TemplateData: map[string]int{"Count": 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2 fichiers", msg)
+}
+
+func TestLocaleLoaderKeySeparator(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{"user": {"profile": {"title": "Profile"}}}`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir, KeySeparator: "/"}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+	require.Len(t, loader.Messages("en"), 1)
+	assert.Equal(t, "user/profile/title", loader.Messages("en")[0].ID)
+
+	// 写回时保持树形结构
+	_, err := loader.WriteMessages("en", map[string][]*i18n.Message{
+		"": {{ID: "user/profile/subtitle", Other: "Details"}},
+	}, false)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "en.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user": {"profile": {"title": "Profile", "subtitle": "Details"}}}`, string(data))
+}
+
+func TestUnflattenMessagesConflict(t *testing.T) {
+	_, err := unflattenMessages([]*i18n.Message{
+		{ID: "user", Other: "User"},
+		{ID: "user.name", Other: "Name"},
+	}, nil, "")
+	assert.Error(t, err)
+
+	tree, err := unflattenMessages([]*i18n.Message{
+		{ID: "a.b", One: "one", Other: "other"},
+	}, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"b": map[string]interface{}{"one": "one", "other": "other"}},
+	}, tree)
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// FormatNumber 按语言格式化数字（分组和小数分隔符），如 en: 1,234.5；de: 1.234,5；ar: ١٬٢٣٤٫٥
+func FormatNumber(tag language.Tag, v interface{}) (string, error) {
+	n, err := numericValue(v)
+	if err != nil {
+		return "", err
+	}
+	return message.NewPrinter(tag).Sprint(number.Decimal(n)), nil
+}
+
+// FormatPercent 按语言格式化百分比，0.25 格式化为 en: 25%；de: 25 %
+func FormatPercent(tag language.Tag, v interface{}) (string, error) {
+	n, err := numericValue(v)
+	if err != nil {
+		return "", err
+	}
+	return message.NewPrinter(tag).Sprint(number.Percent(n)), nil
+}
+
+// FormatCurrency 按语言格式化金额，code 为 ISO 4217 货币代码
+// 小数位数取决于货币（JPY 为 0，KWD 为 3），符号位置取决于语言，如 en: €1,234.50；de: 1.234,50 €
+func FormatCurrency(tag language.Tag, v interface{}, code string) (string, error) {
+	n, err := numericValue(v)
+	if err != nil {
+		return "", err
+	}
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid currency code %q: %w", code, err)
+	}
+
+	p := message.NewPrinter(tag)
+	scale, _ := currency.Standard.Rounding(unit)
+	amount := p.Sprint(number.Decimal(n, number.Scale(scale)))
+	symbol := p.Sprint(currency.Symbol(unit))
+
+	switch currencyPatternOf(tag) {
+	case symbolAfter:
+		return amount + "\u00a0" + symbol, nil
+	case symbolBeforeSpaced:
+		symbol += "\u00a0"
+	}
+	if strings.HasPrefix(amount, "-") {
+		return "-" + symbol + amount[1:], nil
+	}
+	return symbol + amount, nil
+}
+
+// currencyPattern 货币符号相对于数字的位置
+type currencyPattern int
+
+const (
+	symbolBefore       currencyPattern = iota // ¤#,##0.00
+	symbolBeforeSpaced                        // ¤ #,##0.00
+	symbolAfter                               // #,##0.00 ¤
+)
+
+// currencyPatterns 各语言的货币格式（取自 CLDR），先按完整标签查找再按基础语言查找，未列出的语言符号在前
+var currencyPatterns = map[string]currencyPattern{
+	"ar": symbolAfter, "bg": symbolAfter, "cs": symbolAfter, "da": symbolAfter,
+	"de": symbolAfter, "el": symbolAfter, "es": symbolAfter, "et": symbolAfter,
+	"fi": symbolAfter, "fr": symbolAfter, "hr": symbolAfter, "hu": symbolAfter,
+	"it": symbolAfter, "lt": symbolAfter, "lv": symbolAfter, "nb": symbolAfter,
+	"no": symbolAfter, "pl": symbolAfter, "ro": symbolAfter, "ru": symbolAfter,
+	"sk": symbolAfter, "sl": symbolAfter, "sv": symbolAfter, "uk": symbolAfter,
+	"vi": symbolAfter,
+	"nl": symbolBeforeSpaced, "pt": symbolBeforeSpaced,
+	"de-AT": symbolBeforeSpaced, "de-CH": symbolBeforeSpaced, "it-CH": symbolBeforeSpaced,
+	"es-MX": symbolBefore, "es-US": symbolBefore,
+}
+
+func currencyPatternOf(tag language.Tag) currencyPattern {
+	if pattern, ok := currencyPatterns[tag.String()]; ok {
+		return pattern
+	}
+	base, _ := tag.Base()
+	return currencyPatterns[base.String()]
+}
+
+// numericValue 检查并转换数字参数，字符串和 json.Number 会被解析
+func numericValue(v interface{}) (interface{}, error) {
+	switch n := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return n, nil
+	case json.Number:
+		return parseNumber(string(n))
+	case string:
+		return parseNumber(n)
+	}
+	return nil, fmt.Errorf("expected a number, got %T", v)
+}
+
+func parseNumber(s string) (interface{}, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return f, nil
+}
+
+// NumberFuncs 数字格式化的模板函数
+//
+//	{{num .Amount}}              1,234.5
+//	{{currency .Price "EUR"}}    €1,234.50
+//	{{percent .Ratio}}           25%
+func NumberFuncs(tag language.Tag) template.FuncMap {
+	return template.FuncMap{
+		"num": func(v interface{}) (string, error) {
+			return FormatNumber(tag, v)
+		},
+		"currency": func(v interface{}, code string) (string, error) {
+			return FormatCurrency(tag, v, code)
+		},
+		"percent": func(v interface{}) (string, error) {
+			return FormatPercent(tag, v)
+		},
+	}
+}
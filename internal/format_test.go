@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestFormatNumbers(t *testing.T) {
+	tests := []struct {
+		lang                       string
+		number, currency, negative string
+	}{
+		{"en", "1,234,567.891", "€1,234.50", "-¥1,234"},
+		{"de", "1.234.567,891", "1.234,50 €", "-1.234 ¥"},
+		{"zh-CN", "1,234,567.891", "€1,234.50", "-JP¥1,234"},
+		{"ar", "١٬٢٣٤٬٥٦٧٫٨٩١", "١٬٢٣٤٫٥٠ €", "؜-١٬٢٣٤ JP¥"},
+		{"pt-BR", "1.234.567,891", "€ 1.234,50", "-JP¥ 1.234"},
+	}
+
+	for _, tt := range tests {
+		tag := language.MustParse(tt.lang)
+
+		s, err := FormatNumber(tag, 1234567.891)
+		require.NoError(t, err)
+		assert.Equal(t, tt.number, s, tt.lang)
+
+		s, err = FormatCurrency(tag, "1234.5", "EUR")
+		require.NoError(t, err)
+		assert.Equal(t, tt.currency, s, tt.lang)
+
+		s, err = FormatCurrency(tag, -1234.4, "JPY")
+		require.NoError(t, err)
+		assert.Equal(t, tt.negative, s, tt.lang)
+	}
+
+	s, err := FormatPercent(language.German, 0.25)
+	require.NoError(t, err)
+	assert.Equal(t, "25 %", s)
+
+	_, err = FormatCurrency(language.English, 1, "EURO")
+	assert.Error(t, err)
+	_, err = FormatNumber(language.English, "abc")
+	assert.Error(t, err)
+}
+
+func TestNumberFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(NumberFuncs(language.German)).
+		Parse(`{{num .Count}} Artikel für {{currency .Total "EUR"}} ({{percent .Discount}} Rabatt)`))
+
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, map[string]interface{}{"Count": 1200, "Total": 99.9, "Discount": 0.1}))
+	assert.Equal(t, "1.200 Artikel für 99,90 € (10 % Rabatt)", b.String())
+}
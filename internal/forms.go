@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// MessageForm 消息的一个复数形式
+type MessageForm struct {
+	Name  string // zero、one、two、few、many 或 other
+	Value string
+}
+
+// MessageForms 返回消息中非空的复数形式，按 CLDR 顺序排列
+func MessageForms(m *i18n.Message) []MessageForm {
+	var forms []MessageForm
+	for _, form := range []MessageForm{
+		{"zero", m.Zero}, {"one", m.One}, {"two", m.Two},
+		{"few", m.Few}, {"many", m.Many}, {"other", m.Other},
+	} {
+		if form.Value != "" {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// IsPluralMessage 检查消息是否包含 other 之外的复数形式
+func IsPluralMessage(m *i18n.Message) bool {
+	return m != nil && (m.Zero != "" || m.One != "" || m.Two != "" || m.Few != "" || m.Many != "")
+}
+
+// SetMessageForm 按名称设置消息的复数形式
+func SetMessageForm(m *i18n.Message, name, s string) error {
+	switch name {
+	case "zero":
+		m.Zero = s
+	case "one":
+		m.One = s
+	case "two":
+		m.Two = s
+	case "few":
+		m.Few = s
+	case "many":
+		m.Many = s
+	case "other":
+		m.Other = s
+	default:
+		return fmt.Errorf("unsupported plural form: %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageForms(t *testing.T) {
+	m := &i18n.Message{ID: "FILES", Other: "{{.Count}} files"}
+	assert.False(t, IsPluralMessage(m))
+	assert.False(t, IsPluralMessage(nil))
+	assert.Equal(t, []MessageForm{{"other", "{{.Count}} files"}}, MessageForms(m))
+
+	require.NoError(t, SetMessageForm(m, "few", "{{.Count}} pliki"))
+	require.NoError(t, SetMessageForm(m, "one", "{{.Count}} plik"))
+	assert.Error(t, SetMessageForm(m, "several", "x"))
+	assert.True(t, IsPluralMessage(m))
+	assert.Equal(t, []MessageForm{
+		{"one", "{{.Count}} plik"},
+		{"few", "{{.Count}} pliki"},
+		{"other", "{{.Count}} files"},
+	}, MessageForms(m))
+}
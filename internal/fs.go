@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// osFS 基于操作系统文件系统的 fs.FS 实现
+// 与 os.DirFS 不同，它直接接受操作系统路径（包括绝对路径和 ..），
+// 用于在未配置 fs.FS 时保持原有的路径语义
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// isOSFS 检查是否为操作系统文件系统
+func isOSFS(fsys fs.FS) bool {
+	_, ok := fsys.(osFS)
+	return ok
+}
+
+// joinPath 按文件系统类型拼接路径
+// fs.FS 要求使用 / 分隔的相对路径，操作系统文件系统使用本地分隔符
+func joinPath(fsys fs.FS, elem ...string) string {
+	if isOSFS(fsys) {
+		return filepath.Join(elem...)
+	}
+	return path.Join(elem...)
+}
+
+// cleanRoot 规范化根路径，fs.FS 中不允许出现 ./ 前缀
+func cleanRoot(fsys fs.FS, root string) string {
+	if isOSFS(fsys) {
+		return root
+	}
+	return path.Clean(filepath.ToSlash(root))
+}
+
+// fileExists 检查文件是否存在
+func fileExists(fsys fs.FS, filename string) bool {
+	info, err := fs.Stat(fsys, filename)
+	return err == nil && !info.IsDir()
+}
+
+// dirExists 检查目录是否存在
+func dirExists(fsys fs.FS, dirname string) bool {
+	info, err := fs.Stat(fsys, dirname)
+	return err == nil && info.IsDir()
+}
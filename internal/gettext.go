@@ -0,0 +1,865 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// gettext 与 go-i18n 的映射规则:
+//   - msgctxt 存在时作为消息ID，msgid 为源语言文本；否则 msgid 即消息ID
+//   - msgid_plural/msgstr[n] 按 Plural-Forms 表达式映射到 CLDR 复数形式
+//   - "#." 注释对应消息描述，"#, fuzzy" 和空翻译的条目会被忽略
+
+// poEntry PO 文件中的一个条目
+type poEntry struct {
+	context    string
+	id         string
+	idPlural   string
+	str        []string
+	comments   []string
+	fuzzy      bool
+	hasPlural  bool
+	hasContext bool
+	hasMsgstr  bool
+}
+
+// messageID 条目对应的消息ID
+func (e *poEntry) messageID() string {
+	if e.hasContext {
+		return e.context
+	}
+	return e.id
+}
+
+// ParsePO 解析 gettext PO 文件
+func ParsePO(buf []byte, lang string) ([]*i18n.Message, error) {
+	entries, err := parsePOEntries(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var header string
+	var rest []*poEntry
+	for _, entry := range entries {
+		if entry.id == "" && !entry.hasContext {
+			if len(entry.str) > 0 {
+				header = entry.str[0]
+			}
+			continue
+		}
+		rest = append(rest, entry)
+	}
+
+	forms, err := gettextFormsFromHeader(lang, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*i18n.Message
+	for _, entry := range rest {
+		if entry.fuzzy {
+			continue
+		}
+		if m := entry.toMessage(forms); m != nil {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// toMessage 将条目转换为消息，未翻译的条目返回 nil
+func (e *poEntry) toMessage(forms []plural.Form) *i18n.Message {
+	m := &i18n.Message{
+		ID:          e.messageID(),
+		Description: strings.Join(e.comments, "\n"),
+	}
+
+	if !e.hasPlural {
+		if len(e.str) == 0 || e.str[0] == "" {
+			return nil
+		}
+		m.Other = e.str[0]
+		return m
+	}
+
+	translated := false
+	for i, str := range e.str {
+		if str == "" || i >= len(forms) {
+			continue
+		}
+		setPluralForm(m, forms[i], str)
+		translated = true
+	}
+	if !translated {
+		return nil
+	}
+
+	// go-i18n 要求 other 形式存在（如俄语的 other 只用于小数）
+	if m.Other == "" {
+		for i := len(e.str) - 1; i >= 0; i-- {
+			if e.str[i] != "" {
+				m.Other = e.str[i]
+				break
+			}
+		}
+	}
+	return m
+}
+
+// parsePOEntries 解析 PO 文件条目
+func parsePOEntries(buf []byte) ([]*poEntry, error) {
+	var entries []*poEntry
+	entry := &poEntry{}
+	// target 指向当前关键字对应的字符串，用于拼接续行
+	var target *string
+	started := false
+
+	flush := func() {
+		if started {
+			entries = append(entries, entry)
+		}
+		entry = &poEntry{}
+		target = nil
+		started = false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		switch {
+		case line == "":
+			flush()
+			continue
+
+		case strings.HasPrefix(line, "#~"):
+			// 废弃条目
+			continue
+
+		case strings.HasPrefix(line, "#"):
+			// 注释出现在已有 msgstr 之后，说明是新的条目
+			if entry.hasMsgstr {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#."):
+				entry.comments = append(entry.comments, strings.TrimSpace(line[2:]))
+			case strings.HasPrefix(line, "#,"):
+				for _, flag := range strings.Split(line[2:], ",") {
+					if strings.TrimSpace(flag) == "fuzzy" {
+						entry.fuzzy = true
+					}
+				}
+			}
+			continue
+
+		case strings.HasPrefix(line, `"`):
+			if target == nil {
+				return nil, fmt.Errorf("line %d: unexpected string continuation", lineNo)
+			}
+			s, err := unquotePO(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			*target += s
+			continue
+		}
+
+		keyword, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid syntax: %s", lineNo, line)
+		}
+		s, err := unquotePO(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		// msgctxt/msgid 出现在已有 msgstr 之后，说明是新的条目
+		if (keyword == "msgctxt" || keyword == "msgid") && entry.hasMsgstr {
+			flush()
+		}
+		started = true
+
+		switch {
+		case keyword == "msgctxt":
+			entry.context, entry.hasContext = s, true
+			target = &entry.context
+		case keyword == "msgid":
+			entry.id = s
+			target = &entry.id
+		case keyword == "msgid_plural":
+			entry.idPlural, entry.hasPlural = s, true
+			target = &entry.idPlural
+		case keyword == "msgstr":
+			entry.str = []string{s}
+			entry.hasMsgstr = true
+			target = &entry.str[0]
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("line %d: invalid plural index: %s", lineNo, keyword)
+			}
+			for len(entry.str) <= index {
+				entry.str = append(entry.str, "")
+			}
+			entry.str[index] = s
+			entry.hasMsgstr = true
+			target = &entry.str[index]
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword: %s", lineNo, keyword)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return entries, nil
+}
+
+// unquotePO 解析 PO 中的 C 风格字符串
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string: %s", s)
+	}
+	unquoted, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s: %w", s, err)
+	}
+	return unquoted, nil
+}
+
+// ParseMO 解析 gettext 编译后的 MO 文件
+func ParseMO(buf []byte, lang string) ([]*i18n.Message, error) {
+	if len(buf) < 28 {
+		return nil, fmt.Errorf("invalid mo file: too short")
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(buf) == 0x950412de:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(buf) == 0x950412de:
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid mo file: bad magic number")
+	}
+
+	count := int(order.Uint32(buf[8:]))
+	originals := int(order.Uint32(buf[12:]))
+	translations := int(order.Uint32(buf[16:]))
+
+	readString := func(table, i int) (string, error) {
+		pos := table + i*8
+		if pos+8 > len(buf) {
+			return "", fmt.Errorf("invalid mo file: string table out of range")
+		}
+		length := int(order.Uint32(buf[pos:]))
+		offset := int(order.Uint32(buf[pos+4:]))
+		if offset < 0 || length < 0 || offset+length > len(buf) {
+			return "", fmt.Errorf("invalid mo file: string out of range")
+		}
+		return string(buf[offset : offset+length]), nil
+	}
+
+	var header string
+	var entries []*poEntry
+	for i := 0; i < count; i++ {
+		original, err := readString(originals, i)
+		if err != nil {
+			return nil, err
+		}
+		translation, err := readString(translations, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if original == "" {
+			header = translation
+			continue
+		}
+
+		entry := &poEntry{str: strings.Split(translation, "\x00")}
+		if context, id, ok := strings.Cut(original, "\x04"); ok {
+			entry.context, entry.hasContext = context, true
+			original = id
+		}
+		if id, idPlural, ok := strings.Cut(original, "\x00"); ok {
+			entry.id, entry.idPlural, entry.hasPlural = id, idPlural, true
+		} else {
+			entry.id = original
+		}
+		entries = append(entries, entry)
+	}
+
+	forms, err := gettextFormsFromHeader(lang, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*i18n.Message
+	for _, entry := range entries {
+		if m := entry.toMessage(forms); m != nil {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// gettextPluralForms 常用语言的 gettext Plural-Forms
+var gettextPluralForms = map[string]string{
+	"ja": "nplurals=1; plural=0;",
+	"zh": "nplurals=1; plural=0;",
+	"ko": "nplurals=1; plural=0;",
+	"th": "nplurals=1; plural=0;",
+	"vi": "nplurals=1; plural=0;",
+	"id": "nplurals=1; plural=0;",
+	"ms": "nplurals=1; plural=0;",
+	"en": "nplurals=2; plural=(n != 1);",
+	"de": "nplurals=2; plural=(n != 1);",
+	"nl": "nplurals=2; plural=(n != 1);",
+	"sv": "nplurals=2; plural=(n != 1);",
+	"da": "nplurals=2; plural=(n != 1);",
+	"nb": "nplurals=2; plural=(n != 1);",
+	"it": "nplurals=2; plural=(n != 1);",
+	"es": "nplurals=2; plural=(n != 1);",
+	"el": "nplurals=2; plural=(n != 1);",
+	"fi": "nplurals=2; plural=(n != 1);",
+	"et": "nplurals=2; plural=(n != 1);",
+	"hu": "nplurals=2; plural=(n != 1);",
+	"tr": "nplurals=2; plural=(n != 1);",
+	"bg": "nplurals=2; plural=(n != 1);",
+	"ca": "nplurals=2; plural=(n != 1);",
+	"fr": "nplurals=2; plural=(n > 1);",
+	"pt": "nplurals=2; plural=(n > 1);",
+	"hi": "nplurals=2; plural=(n > 1);",
+	"ru": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"uk": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"be": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"hr": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"sr": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"bs": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"pl": "nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"cs": "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+	"sk": "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+	"lt": "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"ro": "nplurals=3; plural=(n==1 ? 0 : (n==0 || (n%100 > 0 && n%100 < 20)) ? 1 : 2);",
+	"sl": "nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100==3 || n%100==4 ? 2 : 3);",
+	"ar": "nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);",
+}
+
+// GettextPluralForms 获取语言的 Plural-Forms 头
+func GettextPluralForms(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", err
+	}
+
+	base, _ := tag.Base()
+	if header, ok := gettextPluralForms[base.String()]; ok {
+		return header, nil
+	}
+
+	// 未知语言按 CLDR 整数复数形式推断常见的规则
+	forms := cldrIntegerForms(tag)
+	switch {
+	case len(forms) == 1:
+		return "nplurals=1; plural=0;", nil
+	case len(forms) == 2 && cldrForm(tag, 0) == plural.Other:
+		return "nplurals=2; plural=(n != 1);", nil
+	case len(forms) == 2:
+		return "nplurals=2; plural=(n > 1);", nil
+	}
+	return "", fmt.Errorf("no gettext plural forms known for %s", lang)
+}
+
+// gettextFormsFromHeader 根据 PO/MO 头中的 Plural-Forms 计算 msgstr[n] 对应的 CLDR 复数形式
+func gettextFormsFromHeader(lang, header string) ([]plural.Form, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return nil, fmt.Errorf("invalid language code %q: %w", lang, err)
+	}
+
+	pluralForms := ""
+	for _, line := range strings.Split(header, "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "Plural-Forms") {
+			pluralForms = strings.TrimSpace(value)
+		}
+	}
+	// POT 模板中的占位头没有实际规则
+	if strings.Contains(pluralForms, "INTEGER") {
+		pluralForms = ""
+	}
+	if pluralForms == "" {
+		if pluralForms, err = GettextPluralForms(lang); err != nil {
+			// 没有可用的规则时按 CLDR 顺序映射
+			return cldrIntegerForms(tag), nil
+		}
+	}
+
+	return gettextForms(tag, pluralForms)
+}
+
+// gettextForms 通过对样本数值求值 plural 表达式，得到每个 msgstr 下标对应的 CLDR 复数形式
+func gettextForms(tag language.Tag, pluralForms string) ([]plural.Form, error) {
+	nplurals, expr, err := parsePluralForms(pluralForms)
+	if err != nil {
+		return nil, err
+	}
+
+	forms := make([]plural.Form, nplurals)
+	found := make([]bool, nplurals)
+	for n := 0; n <= 1000; n++ {
+		index := expr(n)
+		if index < 0 || index >= nplurals || found[index] {
+			continue
+		}
+		forms[index] = cldrForm(tag, n)
+		found[index] = true
+	}
+	for i := range forms {
+		if !found[i] {
+			forms[i] = plural.Other
+		}
+	}
+	return forms, nil
+}
+
+// cldrForm 获取整数 n 的 CLDR 复数形式
+func cldrForm(tag language.Tag, n int) plural.Form {
+	return plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)
+}
+
+// cldrIntegerForms 获取语言在整数上使用的 CLDR 复数形式，按 CLDR 顺序排列
+func cldrIntegerForms(tag language.Tag) []plural.Form {
+	seen := make(map[plural.Form]bool)
+	for n := 0; n <= 1000; n++ {
+		seen[cldrForm(tag, n)] = true
+	}
+
+	var forms []plural.Form
+	for _, form := range []plural.Form{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other} {
+		if seen[form] {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// setPluralForm 设置消息的复数形式
+func setPluralForm(m *i18n.Message, form plural.Form, s string) {
+	switch form {
+	case plural.Zero:
+		m.Zero = s
+	case plural.One:
+		m.One = s
+	case plural.Two:
+		m.Two = s
+	case plural.Few:
+		m.Few = s
+	case plural.Many:
+		m.Many = s
+	default:
+		m.Other = s
+	}
+}
+
+// pluralForm 获取消息的复数形式，缺失时使用 other
+func pluralForm(m *i18n.Message, form plural.Form) string {
+	var s string
+	switch form {
+	case plural.Zero:
+		s = m.Zero
+	case plural.One:
+		s = m.One
+	case plural.Two:
+		s = m.Two
+	case plural.Few:
+		s = m.Few
+	case plural.Many:
+		s = m.Many
+	}
+	if s == "" {
+		s = m.Other
+	}
+	return s
+}
+
+// EncodePO 生成 gettext PO 文件
+// source 为源语言（默认语言）的消息，用作 msgid；translations 为 nil 时生成 POT 模板
+func EncodePO(lang string, source, translations []*i18n.Message) ([]byte, error) {
+	template := translations == nil
+
+	sourceByID := indexMessages(source)
+	translationByID := indexMessages(translations)
+
+	ids := make(map[string]bool)
+	for id := range sourceByID {
+		ids[id] = true
+	}
+	for id := range translationByID {
+		ids[id] = true
+	}
+	sortedIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		sortedIDs = append(sortedIDs, id)
+	}
+	sort.Strings(sortedIDs)
+
+	var b bytes.Buffer
+	b.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	b.WriteString("\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	b.WriteString("\"Content-Transfer-Encoding: 8bit\\n\"\n")
+
+	var forms []plural.Form
+	if template {
+		b.WriteString("\"Plural-Forms: nplurals=INTEGER; plural=EXPRESSION;\\n\"\n")
+	} else {
+		pluralForms, err := GettextPluralForms(lang)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, err
+		}
+		if forms, err = gettextForms(tag, pluralForms); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "\"Language: %s\\n\"\n", lang)
+		fmt.Fprintf(&b, "\"Plural-Forms: %s\\n\"\n", pluralForms)
+	}
+
+	for _, id := range sortedIDs {
+		src, translation := sourceByID[id], translationByID[id]
+
+		b.WriteString("\n")
+		description := ""
+		if translation != nil {
+			description = translation.Description
+		}
+		if description == "" && src != nil {
+			description = src.Description
+		}
+		for _, line := range strings.Split(description, "\n") {
+			if line != "" {
+				fmt.Fprintf(&b, "#. %s\n", line)
+			}
+		}
+
+		// 源文本缺失时以消息ID作为 msgid
+		msgid, msgidPlural := id, id
+		if src != nil {
+			msgid, msgidPlural = src.Other, src.Other
+			if src.One != "" {
+				msgid = src.One
+			}
+		}
+
+		fmt.Fprintf(&b, "msgctxt %s\n", quotePO(id))
+		fmt.Fprintf(&b, "msgid %s\n", quotePO(msgid))
+
+		if !IsPluralMessage(src) && !IsPluralMessage(translation) {
+			str := ""
+			if translation != nil {
+				str = translation.Other
+			}
+			fmt.Fprintf(&b, "msgstr %s\n", quotePO(str))
+			continue
+		}
+
+		fmt.Fprintf(&b, "msgid_plural %s\n", quotePO(msgidPlural))
+		if template {
+			b.WriteString("msgstr[0] \"\"\nmsgstr[1] \"\"\n")
+			continue
+		}
+		for i, form := range forms {
+			str := ""
+			if translation != nil {
+				str = pluralForm(translation, form)
+			}
+			fmt.Fprintf(&b, "msgstr[%d] %s\n", i, quotePO(str))
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// quotePO 生成 PO 字符串
+func quotePO(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// indexMessages 按ID索引消息
+func indexMessages(messages []*i18n.Message) map[string]*i18n.Message {
+	index := make(map[string]*i18n.Message, len(messages))
+	for _, m := range messages {
+		index[m.ID] = m
+	}
+	return index
+}
+
+// parsePluralForms 解析 "nplurals=N; plural=EXPR;" 并编译 plural 表达式
+func parsePluralForms(s string) (int, func(n int) int, error) {
+	var nplurals int
+	var exprSource string
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "nplurals":
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n <= 0 {
+				return 0, nil, fmt.Errorf("invalid nplurals in %q", s)
+			}
+			nplurals = n
+		case "plural":
+			exprSource = value
+		}
+	}
+	if nplurals == 0 || exprSource == "" {
+		return 0, nil, fmt.Errorf("invalid Plural-Forms: %q", s)
+	}
+
+	p := &pluralParser{src: exprSource}
+	node, err := p.parse()
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid plural expression %q: %w", exprSource, err)
+	}
+	return nplurals, node, nil
+}
+
+// pluralParser gettext plural 表达式（C 语法子集）的递归下降解析器
+type pluralParser struct {
+	src string
+	pos int
+}
+
+type pluralExpr = func(n int) int
+
+func (p *pluralParser) parse() (pluralExpr, error) {
+	expr, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at %d", p.src[p.pos:], p.pos)
+	}
+	return expr, nil
+}
+
+func (p *pluralParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *pluralParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *pluralParser) ternary() (pluralExpr, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume("?") {
+		return cond, nil
+	}
+	then, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(":") {
+		return nil, fmt.Errorf("expected ':' at %d", p.pos)
+	}
+	otherwise, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int) int {
+		if cond(n) != 0 {
+			return then(n)
+		}
+		return otherwise(n)
+	}, nil
+}
+
+// pluralOperators 按优先级从低到高排列的二元运算符
+var pluralOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *pluralParser) binary(level int) (pluralExpr, error) {
+	if level == len(pluralOperators) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := ""
+		for _, candidate := range pluralOperators[level] {
+			if p.consume(candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = combinePlural(op, left, right)
+	}
+}
+
+func combinePlural(op string, left, right pluralExpr) pluralExpr {
+	boolInt := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	return func(n int) int {
+		switch op {
+		case "||":
+			return boolInt(left(n) != 0 || right(n) != 0)
+		case "&&":
+			return boolInt(left(n) != 0 && right(n) != 0)
+		}
+
+		l, r := left(n), right(n)
+		switch op {
+		case "==":
+			return boolInt(l == r)
+		case "!=":
+			return boolInt(l != r)
+		case "<=":
+			return boolInt(l <= r)
+		case ">=":
+			return boolInt(l >= r)
+		case "<":
+			return boolInt(l < r)
+		case ">":
+			return boolInt(l > r)
+		case "+":
+			return l + r
+		case "-":
+			return l - r
+		case "*":
+			return l * r
+		case "/":
+			if r == 0 {
+				return 0
+			}
+			return l / r
+		default:
+			if r == 0 {
+				return 0
+			}
+			return l % r
+		}
+	}
+}
+
+func (p *pluralParser) unary() (pluralExpr, error) {
+	if p.consume("!") {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int {
+			if operand(n) == 0 {
+				return 1
+			}
+			return 0
+		}, nil
+	}
+	return p.primary()
+}
+
+func (p *pluralParser) primary() (pluralExpr, error) {
+	if p.consume("(") {
+		expr, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, fmt.Errorf("expected ')' at %d", p.pos)
+		}
+		return expr, nil
+	}
+
+	if p.consume("n") {
+		return func(n int) int { return n }, nil
+	}
+
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, fmt.Errorf("unexpected token at %d", p.pos)
+	}
+	value, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return nil, err
+	}
+	return func(int) int { return value }, nil
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+const russianPO = `msgid ""
+msgstr ""
+"Language: ru\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && "
+"n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#. Greeting on the home page
+#: home.go:12
+msgctxt "WELCOME"
+msgid "Welcome"
+msgstr "Добро пожаловать"
+
+msgctxt "FILES"
+msgid "{{.Count}} file"
+msgid_plural "{{.Count}} files"
+msgstr[0] "{{.Count}} файл"
+msgstr[1] "{{.Count}} файла"
+msgstr[2] "{{.Count}} файлов"
+
+#, fuzzy
+msgctxt "DRAFT"
+msgid "Draft"
+msgstr "Черновик"
+
+msgid "Untranslated"
+msgstr ""
+
+msgid "Quote \"me\""
+msgstr "Цитата \"я\"\n"
+`
+
+func TestParsePO(t *testing.T) {
+	messages, err := ParsePO([]byte(russianPO), "ru")
+	require.NoError(t, err)
+
+	byID := indexMessages(messages)
+	require.Len(t, byID, 3)
+
+	assert.Equal(t, "Добро пожаловать", byID["WELCOME"].Other)
+	assert.Equal(t, "Greeting on the home page", byID["WELCOME"].Description)
+	assert.Equal(t, "Цитата \"я\"\n", byID[`Quote "me"`].Other)
+
+	files := byID["FILES"]
+	assert.Equal(t, "{{.Count}} файл", files.One)
+	assert.Equal(t, "{{.Count}} файла", files.Few)
+	assert.Equal(t, "{{.Count}} файлов", files.Many)
+	assert.Equal(t, "{{.Count}} файлов", files.Other)
+
+	bundle := i18n.NewBundle(language.English)
+	require.NoError(t, bundle.AddMessages(language.Russian, messages...))
+	loc := i18n.NewLocalizer(bundle, "ru")
+	for count, want := range map[int]string{1: "1 файл", 3: "3 файла", 11: "11 файлов"} {
+		msg, err := loc.Localize(&i18n.LocalizeConfig{
+			MessageID:    "FILES",
+			PluralCount:  count,
+			TemplateData: map[string]int{"Count": count},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, want, msg)
+	}
+}
+
+func TestParsePOErrors(t *testing.T) {
+	_, err := ParsePO([]byte("msgid \"a\"\nmsgstr unquoted\n"), "en")
+	assert.Error(t, err)
+
+	_, err = ParsePO([]byte("msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=(n !=;\\n\"\n"), "en")
+	assert.Error(t, err)
+}
+
+// encodeMO 生成测试用的 MO 文件
+func encodeMO(order binary.ByteOrder, originals, translations []string) []byte {
+	const headerSize = 28
+	count := len(originals)
+	originalTable := headerSize
+	translationTable := originalTable + count*8
+	offset := translationTable + count*8
+
+	var table, data bytes.Buffer
+	writeTable := func(strs []string) {
+		for _, s := range strs {
+			binary.Write(&table, order, uint32(len(s)))
+			binary.Write(&table, order, uint32(offset+data.Len()))
+			data.WriteString(s)
+			data.WriteByte(0)
+		}
+	}
+	writeTable(originals)
+	writeTable(translations)
+
+	var buf bytes.Buffer
+	for _, v := range []uint32{0x950412de, 0, uint32(count), uint32(originalTable), uint32(translationTable), 0, 0} {
+		binary.Write(&buf, order, v)
+	}
+	buf.Write(table.Bytes())
+	buf.Write(data.Bytes())
+	return buf.Bytes()
+}
+
+func TestParseMO(t *testing.T) {
+	originals := []string{
+		"",
+		"WELCOME\x04Welcome",
+		"FILES\x04{{.Count}} file\x00{{.Count}} files",
+		"Plain",
+	}
+	translations := []string{
+		"Language: fr\nPlural-Forms: nplurals=2; plural=(n > 1);\n",
+		"Bienvenue",
+		"{{.Count}} fichier\x00{{.Count}} fichiers",
+		"Simple",
+	}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		messages, err := ParseMO(encodeMO(order, originals, translations), "fr")
+		require.NoError(t, err)
+
+		byID := indexMessages(messages)
+		require.Len(t, byID, 3)
+		assert.Equal(t, "Bienvenue", byID["WELCOME"].Other)
+		assert.Equal(t, "Simple", byID["Plain"].Other)
+		assert.Equal(t, "{{.Count}} fichier", byID["FILES"].One)
+		assert.Equal(t, "{{.Count}} fichiers", byID["FILES"].Other)
+	}
+
+	_, err := ParseMO([]byte("not a mo file at all, really"), "fr")
+	assert.Error(t, err)
+}
+
+func TestEncodePORoundTrip(t *testing.T) {
+	source := []*i18n.Message{
+		{ID: "WELCOME", Description: "Greeting", Other: "Welcome"},
+		{ID: "FILES", One: "{{.Count}} file", Other: "{{.Count}} files"},
+	}
+	translations := []*i18n.Message{
+		{ID: "WELCOME", Other: "Добро пожаловать"},
+		{ID: "FILES", One: "{{.Count}} файл", Few: "{{.Count}} файла", Many: "{{.Count}} файлов", Other: "{{.Count}} файла"},
+	}
+
+	data, err := EncodePO("ru", source, translations)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "#. Greeting\nmsgctxt \"WELCOME\"\nmsgid \"Welcome\"\n")
+	assert.Contains(t, string(data), "msgid_plural \"{{.Count}} files\"\n")
+
+	messages, err := ParsePO(data, "ru")
+	require.NoError(t, err)
+	byID := indexMessages(messages)
+	assert.Equal(t, "Добро пожаловать", byID["WELCOME"].Other)
+	assert.Equal(t, "Greeting", byID["WELCOME"].Description)
+	assert.Equal(t, "{{.Count}} файл", byID["FILES"].One)
+	assert.Equal(t, "{{.Count}} файла", byID["FILES"].Few)
+	assert.Equal(t, "{{.Count}} файлов", byID["FILES"].Many)
+
+	// POT 模板不包含翻译，解析后没有消息
+	pot, err := EncodePO("en", source, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(pot), "nplurals=INTEGER")
+	messages, err = ParsePO(pot, "en")
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestLocaleLoaderGettext(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `[{"id": "WELCOME", "translation": "Welcome"}]`,
+		"ru.po":   russianPO,
+	})
+
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, bundle)
+	require.NoError(t, loader.LoadLocales())
+	assert.ElementsMatch(t, []string{"en", "ru"}, loader.Languages())
+
+	loc := i18n.NewLocalizer(bundle, "ru")
+	msg, err := loc.Localize(&i18n.LocalizeConfig{MessageID: "WELCOME"})
+	require.NoError(t, err)
+	assert.Equal(t, "Добро пожаловать", msg)
+
+	ids := make([]string, 0)
+	for _, m := range loader.Messages("ru") {
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []string{"FILES", "Quote \"me\"", "WELCOME"}, ids)
+}
@@ -0,0 +1,733 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// MessageFormat 消息的语法
+type MessageFormat string
+
+const (
+	// TemplateFormat Go 模板语法（go-i18n 默认），如 "Hello {{.Name}}"
+	TemplateFormat MessageFormat = "template"
+	// ICUFormat ICU MessageFormat 语法，如 "{count, plural, one {# file} other {# files}}"
+	ICUFormat MessageFormat = "icu"
+)
+
+// ICUMessage 解析后的 ICU MessageFormat 消息
+//
+// 支持的参数类型：
+//
+//	{name}                                  简单参数
+//	{n, number} {n, number, integer|percent} 数字
+//	{d, date, short|medium|long|full}       按语言格式化日期（time.Time），time 类型同理
+//	{n, plural, offset:1 =0 {...} one {...} other {...}}  基数复数，# 替换为数字
+//	{n, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}  序数复数
+//	{gender, select, female {...} male {...} other {...}}  选择
+//
+// 单引号用于转义：连续两个单引号表示一个单引号，'{' 开始的引用文本中的 { } # 按字面输出
+type ICUMessage struct {
+	pattern string
+	nodes   []icuNode
+}
+
+// icuNode 消息片段
+type icuNode interface{}
+
+// icuText 纯文本
+type icuText string
+
+// icuPound 复数子消息中的 #
+type icuPound struct{}
+
+// icuArg 参数
+type icuArg struct {
+	name   string
+	kind   string // 为空表示简单参数；number、date、time、plural、selectordinal、select
+	style  string
+	offset float64
+	cases  map[string][]icuNode // 复数和选择的子消息，精确值以 "=" 开头
+}
+
+// ICUSyntaxError ICU 消息语法错误
+type ICUSyntaxError struct {
+	Pattern string
+	Offset  int
+	Msg     string
+}
+
+// Error 实现 error 接口
+func (e *ICUSyntaxError) Error() string {
+	return fmt.Sprintf("invalid ICU message at offset %d: %s", e.Offset, e.Msg)
+}
+
+// ParseICU 解析 ICU MessageFormat 消息
+func ParseICU(pattern string) (*ICUMessage, error) {
+	p := &icuParser{src: pattern}
+	nodes, err := p.parseMessage(0, false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return &ICUMessage{pattern: pattern, nodes: nodes}, nil
+}
+
+// ValidateICUMessage 检查消息的所有复数形式是否为合法的 ICU MessageFormat
+func ValidateICUMessage(m *i18n.Message) error {
+	for _, form := range MessageForms(m) {
+		if _, err := ParseICU(form.Value); err != nil {
+			if form.Name == "other" {
+				return fmt.Errorf("message %s: %w", m.ID, err)
+			}
+			return fmt.Errorf("message %s (%s): %w", m.ID, form.Name, err)
+		}
+	}
+	return nil
+}
+
+// String 返回原始消息
+func (m *ICUMessage) String() string {
+	return m.pattern
+}
+
+// Pseudolocalize 返回伪本地化的消息，只转换文本，参数、复数和选择的关键字以及 # 保持不变
+func (m *ICUMessage) Pseudolocalize(locale PseudoLocale) *ICUMessage {
+	letters := 0
+	nodes := pseudolocalizeNodes(m.nodes, locale, &letters)
+
+	prefix, suffix := locale.affixes(letters)
+	if prefix != "" {
+		nodes = append([]icuNode{icuText(prefix)}, nodes...)
+	}
+	if suffix != "" {
+		nodes = append(nodes, icuText(suffix))
+	}
+	return &ICUMessage{pattern: m.pattern, nodes: nodes}
+}
+
+func pseudolocalizeNodes(nodes []icuNode, locale PseudoLocale, letters *int) []icuNode {
+	result := make([]icuNode, len(nodes))
+	for i, node := range nodes {
+		switch n := node.(type) {
+		case icuText:
+			var b strings.Builder
+			*letters += locale.transformText(&b, string(n))
+			result[i] = icuText(b.String())
+		case *icuArg:
+			if n.cases == nil {
+				result[i] = n
+				continue
+			}
+			arg := *n
+			arg.cases = make(map[string][]icuNode, len(n.cases))
+			for selector, sub := range n.cases {
+				// 只统计 other 分支的字母数，避免多个分支重复计算
+				count := 0
+				arg.cases[selector] = pseudolocalizeNodes(sub, locale, &count)
+				if selector == "other" {
+					*letters += count
+				}
+			}
+			result[i] = &arg
+		default:
+			result[i] = node
+		}
+	}
+	return result
+}
+
+// Format 使用 tag 对应语言的 CLDR 复数规则和 args 中的参数格式化消息
+// 缺少的简单参数原样输出为 {name}；复数和选择参数缺失或类型不正确时返回错误
+func (m *ICUMessage) Format(tag language.Tag, args map[string]interface{}) (string, error) {
+	f := &icuFormatter{tag: tag, args: args, printer: message.NewPrinter(tag)}
+	var b strings.Builder
+	if err := f.format(&b, m.nodes, ""); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// icuParser ICU 消息解析器
+type icuParser struct {
+	src string
+	pos int
+
+	anyStyle bool // 不检查数字、日期和时间参数的样式（只转换、不格式化的消息）
+}
+
+func (p *icuParser) errorf(format string, args ...interface{}) error {
+	return &ICUSyntaxError{Pattern: p.src, Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseMessage 解析消息直到 } 或结尾，depth > 0 时必须以 } 结束；inPlural 表示 # 需要替换
+func (p *icuParser) parseMessage(depth int, inPlural bool) ([]icuNode, error) {
+	var nodes []icuNode
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, icuText(text.String()))
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\'':
+			p.parseQuoted(&text, inPlural)
+
+		case c == '{':
+			flush()
+			arg, err := p.parseArg(depth, inPlural)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, arg)
+
+		case c == '}':
+			if depth == 0 {
+				return nil, p.errorf("unmatched '}'")
+			}
+			flush()
+			return nodes, nil
+
+		case c == '#' && inPlural:
+			flush()
+			nodes = append(nodes, icuPound{})
+			p.pos++
+
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	if depth > 0 {
+		return nil, p.errorf("unclosed '{'")
+	}
+	flush()
+	return nodes, nil
+}
+
+// parseQuoted 处理单引号：连续两个单引号为一个单引号；后跟 { } # | 时开始引用文本，直到下一个单独的单引号
+func (p *icuParser) parseQuoted(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos < len(p.src) && p.src[p.pos] == '\'' {
+		text.WriteByte('\'')
+		p.pos++
+		return
+	}
+	if p.pos >= len(p.src) || !strings.ContainsRune("{}|", rune(p.src[p.pos])) && !(inPlural && p.src[p.pos] == '#') {
+		text.WriteByte('\'')
+		return
+	}
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteByte(c)
+			continue
+		}
+		if p.pos < len(p.src) && p.src[p.pos] == '\'' {
+			text.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+// parseArg 解析 {name[, type[, style]]}
+func (p *icuParser) parseArg(depth int, inPlural bool) (icuNode, error) {
+	p.pos++ // {
+	p.skipSpace()
+	name := p.parseIdentifier()
+	if name == "" {
+		return nil, p.errorf("expected argument name")
+	}
+	p.skipSpace()
+
+	arg := &icuArg{name: name}
+	if p.consume('}') {
+		return arg, nil
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument %s", name)
+	}
+
+	p.skipSpace()
+	arg.kind = p.parseIdentifier()
+	p.skipSpace()
+
+	switch arg.kind {
+	case "number", "date", "time":
+		if p.consume(',') {
+			p.skipSpace()
+			arg.style = strings.TrimSpace(p.parseStyle())
+		}
+		if !p.anyStyle {
+			if err := validateICUStyle(arg.kind, arg.style); err != nil {
+				return nil, p.errorf("argument %s: %v", name, err)
+			}
+		}
+		if !p.consume('}') {
+			return nil, p.errorf("expected '}' after argument %s", name)
+		}
+		return arg, nil
+
+	case "plural", "selectordinal", "select":
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after %s in argument %s", arg.kind, name)
+		}
+		if err := p.parseCases(arg, depth, inPlural); err != nil {
+			return nil, err
+		}
+		return arg, nil
+
+	case "":
+		return nil, p.errorf("expected argument type for %s", name)
+
+	default:
+		return nil, p.errorf("unsupported argument type %q", arg.kind)
+	}
+}
+
+// parseCases 解析复数和选择参数的子消息
+func (p *icuParser) parseCases(arg *icuArg, depth int, inPlural bool) error {
+	arg.cases = make(map[string][]icuNode)
+	isPlural := arg.kind != "select"
+
+	p.skipSpace()
+	if isPlural && strings.HasPrefix(p.src[p.pos:], "offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		offset, err := strconv.ParseFloat(p.parseIdentifier(), 64)
+		if err != nil || offset < 0 {
+			return p.errorf("invalid offset in argument %s", arg.name)
+		}
+		arg.offset = offset
+	}
+
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			break
+		}
+
+		start := p.pos
+		selector := p.parseIdentifier()
+		if selector == "" {
+			return p.errorf("expected selector in argument %s", arg.name)
+		}
+		if err := validateICUSelector(arg.kind, selector); err != nil {
+			p.pos = start
+			return p.errorf("argument %s: %v", arg.name, err)
+		}
+		if _, exists := arg.cases[selector]; exists {
+			p.pos = start
+			return p.errorf("duplicate selector %q in argument %s", selector, arg.name)
+		}
+
+		p.skipSpace()
+		if !p.consume('{') {
+			return p.errorf("expected '{' after selector %s", selector)
+		}
+		nodes, err := p.parseMessage(depth+1, isPlural || inPlural)
+		if err != nil {
+			return err
+		}
+		p.pos++ // }
+		arg.cases[selector] = nodes
+	}
+
+	if _, ok := arg.cases["other"]; !ok {
+		return p.errorf("argument %s is missing the 'other' case", arg.name)
+	}
+	return nil
+}
+
+// parseStyle 读取参数样式直到对应的 }（不含）
+func (p *icuParser) parseStyle() string {
+	start := p.pos
+	nested := 0
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '{':
+			nested++
+		case '}':
+			if nested == 0 {
+				return p.src[start:p.pos]
+			}
+			nested--
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// parseIdentifier 读取名称、类型或选择器，直到空白或语法字符
+func (p *icuParser) parseIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune("{},#' \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *icuParser) skipSpace() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *icuParser) consume(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// icuPluralKeywords CLDR 复数关键字
+var icuPluralKeywords = map[string]plural.Form{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+// validateICUSelector 检查复数参数的选择器是否为 CLDR 关键字或 =N
+func validateICUSelector(kind, selector string) error {
+	if kind == "select" {
+		return nil
+	}
+	if strings.HasPrefix(selector, "=") {
+		if _, err := strconv.ParseFloat(selector[1:], 64); err != nil {
+			return fmt.Errorf("invalid explicit value %q", selector)
+		}
+		return nil
+	}
+	if _, ok := icuPluralKeywords[selector]; !ok {
+		return fmt.Errorf("invalid plural keyword %q", selector)
+	}
+	return nil
+}
+
+// validateICUStyle 检查数字、日期和时间参数的样式
+func validateICUStyle(kind, style string) error {
+	if kind == "number" {
+		switch style {
+		case "", "integer", "percent":
+			return nil
+		}
+		return fmt.Errorf("unsupported number style %q", style)
+	}
+	_, err := ParseDateStyle(style)
+	return err
+}
+
+// icuFormatter ICU 消息格式化器
+type icuFormatter struct {
+	tag     language.Tag
+	args    map[string]interface{}
+	printer *message.Printer
+}
+
+// format 输出消息片段，pound 为当前复数子消息中 # 的值
+func (f *icuFormatter) format(b *strings.Builder, nodes []icuNode, pound string) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case icuText:
+			b.WriteString(string(n))
+		case icuPound:
+			b.WriteString(pound)
+		case *icuArg:
+			if err := f.formatArg(b, n, pound); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f *icuFormatter) formatArg(b *strings.Builder, arg *icuArg, pound string) error {
+	value, ok := f.args[arg.name]
+	if !ok && arg.cases == nil {
+		b.WriteString("{" + arg.name + "}")
+		return nil
+	}
+
+	switch arg.kind {
+	case "":
+		b.WriteString(fmt.Sprint(value))
+		return nil
+
+	case "number":
+		n, err := icuNumber(value)
+		if err != nil {
+			return fmt.Errorf("argument %s: %w", arg.name, err)
+		}
+		b.WriteString(f.formatNumber(n, arg.style))
+		return nil
+
+	case "date", "time":
+		t, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("argument %s: expected time.Time, got %T", arg.name, value)
+		}
+		style, _ := ParseDateStyle(arg.style)
+		if arg.kind == "date" {
+			b.WriteString(FormatDate(f.tag, t, style))
+		} else {
+			b.WriteString(FormatTime(f.tag, t, style))
+		}
+		return nil
+
+	case "select":
+		selector := "other"
+		if ok {
+			if _, exists := arg.cases[fmt.Sprint(value)]; exists {
+				selector = fmt.Sprint(value)
+			}
+		}
+		return f.format(b, arg.cases[selector], pound)
+
+	default: // plural, selectordinal
+		if !ok {
+			return fmt.Errorf("argument %s: missing plural count", arg.name)
+		}
+		n, err := icuNumber(value)
+		if err != nil {
+			return fmt.Errorf("argument %s: %w", arg.name, err)
+		}
+		nodes := arg.cases[f.pluralSelector(arg, n)]
+		return f.format(b, nodes, f.formatNumber(n-arg.offset, ""))
+	}
+}
+
+// pluralSelector 选择复数子消息：先匹配 =N，再按 CLDR 规则匹配扣除 offset 后的值
+func (f *icuFormatter) pluralSelector(arg *icuArg, n float64) string {
+	for selector := range arg.cases {
+		if !strings.HasPrefix(selector, "=") {
+			continue
+		}
+		if exact, err := strconv.ParseFloat(selector[1:], 64); err == nil && exact == n {
+			return selector
+		}
+	}
+
+	rules := plural.Cardinal
+	if arg.kind == "selectordinal" {
+		rules = plural.Ordinal
+	}
+	i, v, w, fraction, t := pluralOperands(n - arg.offset)
+	form := rules.MatchPlural(f.tag, i, v, w, fraction, t)
+
+	for keyword, kf := range icuPluralKeywords {
+		if kf == form {
+			if _, ok := arg.cases[keyword]; ok {
+				return keyword
+			}
+		}
+	}
+	return "other"
+}
+
+// formatNumber 按语言格式化数字
+func (f *icuFormatter) formatNumber(n float64, style string) string {
+	switch style {
+	case "integer":
+		return f.printer.Sprint(number.Decimal(math.Round(n), number.MaxFractionDigits(0)))
+	case "percent":
+		return f.printer.Sprint(number.Percent(n))
+	}
+	if n == math.Trunc(n) && math.Abs(n) < 1e15 {
+		return f.printer.Sprint(number.Decimal(int64(n)))
+	}
+	return f.printer.Sprint(number.Decimal(n))
+}
+
+// pluralOperands 计算 CLDR 复数规则的操作数（i 整数部分，v/w 小数位数，f/t 小数部分）
+func pluralOperands(n float64) (i, v, w, f, t int) {
+	s := strconv.FormatFloat(math.Abs(n), 'f', -1, 64)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	i, _ = strconv.Atoi(intPart)
+	if fracPart == "" {
+		return i, 0, 0, 0, 0
+	}
+
+	v = len(fracPart)
+	f, _ = strconv.Atoi(fracPart)
+	trimmed := strings.TrimRight(fracPart, "0")
+	w = len(trimmed)
+	t, _ = strconv.Atoi("0" + trimmed)
+	return i, v, w, f, t
+}
+
+// icuNumber 将参数转换为数字
+func icuNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("expected a number, got %T", value)
+}
+
+// Arguments 返回消息中使用的参数名（已排序）
+func (m *ICUMessage) Arguments() []string {
+	seen := make(map[string]bool)
+	var walk func(nodes []icuNode)
+	walk = func(nodes []icuNode) {
+		for _, node := range nodes {
+			arg, ok := node.(*icuArg)
+			if !ok {
+				continue
+			}
+			seen[arg.name] = true
+			for _, sub := range arg.cases {
+				walk(sub)
+			}
+		}
+	}
+	walk(m.nodes)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ICUToTemplate 将 ICU 消息转换为 Go 模板语法的消息，用于导入 Flutter ARB 等客户端的消息
+// 参数转换为 {{.name}}，数字、日期和时间参数的样式被忽略（不检查是否支持）；
+// 最多一个基数复数参数，其前后的文本并入每个复数形式，# 替换为复数变量，没有对应形式的 =0/=1/=2 作为 zero/one/two；
+// 不支持 select、selectordinal、嵌套的复数参数和 offset
+func ICUToTemplate(pattern string) (*i18n.Message, error) {
+	p := &icuParser{src: pattern, anyStyle: true}
+	nodes, err := p.parseMessage(0, false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+
+	var pluralArg *icuArg
+	index := -1
+	for i, node := range nodes {
+		if arg, ok := node.(*icuArg); ok && arg.kind == "plural" {
+			if pluralArg != nil {
+				return nil, fmt.Errorf("multiple plural arguments are not supported")
+			}
+			pluralArg, index = arg, i
+		}
+	}
+
+	m := &i18n.Message{}
+	if pluralArg == nil {
+		m.Other, err = icuTemplateText(nodes, "")
+		return m, err
+	}
+	if pluralArg.offset != 0 {
+		return nil, fmt.Errorf("plural offset is not supported")
+	}
+
+	prefix, err := icuTemplateText(nodes[:index], "")
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := icuTemplateText(nodes[index+1:], "")
+	if err != nil {
+		return nil, err
+	}
+	exact := make(map[string]string)
+	for selector, sub := range pluralArg.cases {
+		text, err := icuTemplateText(sub, pluralArg.name)
+		if err != nil {
+			return nil, err
+		}
+		text = prefix + text + suffix
+		if strings.HasPrefix(selector, "=") {
+			exact[selector] = text
+			continue
+		}
+		if err := SetMessageForm(m, selector, text); err != nil {
+			return nil, err
+		}
+	}
+
+	// 精确匹配 =0/=1/=2 在没有对应复数形式时作为近似
+	for selector, form := range map[string]*string{"=0": &m.Zero, "=1": &m.One, "=2": &m.Two} {
+		if text, ok := exact[selector]; ok && *form == "" {
+			*form = text
+		}
+	}
+	return m, nil
+}
+
+// icuTemplateText 将消息片段转换为 Go 模板文本，pluralVar 为当前复数变量（# 替换为该变量）
+func icuTemplateText(nodes []icuNode, pluralVar string) (string, error) {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case icuText:
+			b.WriteString(string(n))
+		case icuPound:
+			b.WriteString("{{." + pluralVar + "}}")
+		case *icuArg:
+			switch n.kind {
+			case "", "number", "date", "time":
+				b.WriteString("{{." + n.name + "}}")
+			case "plural":
+				return "", fmt.Errorf("nested plural arguments are not supported")
+			default:
+				return "", fmt.Errorf("%s arguments are not supported", n.kind)
+			}
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestICUMessageFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		pattern string
+		args    map[string]interface{}
+		want    string
+	}{
+		{"simple", "en", "Hello {name}!", map[string]interface{}{"name": "Ann"}, "Hello Ann!"},
+		{"missing arg", "en", "Hello {name}!", nil, "Hello {name}!"},
+		{"plural one", "en", "{count, plural, one {# file} other {# files}}", map[string]interface{}{"count": 1}, "1 file"},
+		{"plural other", "en", "{count, plural, one {# file} other {# files}}", map[string]interface{}{"count": 1200}, "1,200 files"},
+		{"plural exact", "en", "{count, plural, =0 {no files} one {# file} other {# files}}", map[string]interface{}{"count": 0}, "no files"},
+		{"plural decimal", "en", "{count, plural, one {# file} other {# files}}", map[string]interface{}{"count": 1.5}, "1.5 files"},
+		{"plural offset", "en", "{n, plural, offset:1 =0 {nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}",
+			map[string]interface{}{"n": 3, "host": "Ann"}, "Ann and 2 others"},
+		{"russian few", "ru", "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}", map[string]interface{}{"n": 3}, "3 файла"},
+		{"russian many", "ru", "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}", map[string]interface{}{"n": 11}, "11 файлов"},
+		{"ordinal", "en", "{n, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", map[string]interface{}{"n": 23}, "23rd"},
+		{"ordinal teen", "en", "{n, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", map[string]interface{}{"n": 12}, "12th"},
+		{"select", "en", "{gender, select, female {She} male {He} other {They}} replied", map[string]interface{}{"gender": "female"}, "She replied"},
+		{"select other", "en", "{gender, select, female {She} other {They}} replied", nil, "They replied"},
+		{"nested", "en", "{gender, select, female {{count, plural, one {She has # file} other {She has # files}}} other {{count, plural, one {They have # file} other {They have # files}}}}",
+			map[string]interface{}{"gender": "female", "count": 2}, "She has 2 files"},
+		{"quoted", "en", "It''s '{literal}' and '#' {n, plural, other {'#' is #}}", map[string]interface{}{"n": 5}, "It's {literal} and '#' # is 5"},
+		{"number", "de", "{n, number} / {n, number, integer} / {p, number, percent}", map[string]interface{}{"n": 1234.5, "p": 0.25}, "1.234,5 / 1.235 / 25\u00a0%"},
+		{"date", "de", "{at, date, long} {at, time, short}", map[string]interface{}{"at": time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC)}, "5. März 2024 14:07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := ParseICU(tt.pattern)
+			require.NoError(t, err)
+
+			got, err := msg.Format(language.MustParse(tt.lang), tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseICUErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"Hello {name",
+		"Hello name}",
+		"{count, plural, one {# file}}",
+		"{count, plural, single {# file} other {# files}}",
+		"{count, plural, one {# file} one {# files} other {}}",
+		"{count, choice, 0#none|1#one}",
+		"{n, number, currency/EUR}",
+		"{{.Name}}",
+	} {
+		_, err := ParseICU(pattern)
+		var syntaxErr *ICUSyntaxError
+		assert.ErrorAs(t, err, &syntaxErr, pattern)
+	}
+
+	msg, err := ParseICU("{count, plural, other {#}}")
+	require.NoError(t, err)
+	_, err = msg.Format(language.English, map[string]interface{}{"count": "many"})
+	assert.Error(t, err)
+}
+
+func TestICUMessageArguments(t *testing.T) {
+	msg, err := ParseICU("{gender, select, female {{count, plural, one {{host} has # file} other {{host} has # files}}} other {{name}}}")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"count", "gender", "host", "name"}, msg.Arguments())
+
+	msg, err = ParseICU("No arguments")
+	require.NoError(t, err)
+	assert.Empty(t, msg.Arguments())
+}
+
+func TestICUToTemplate(t *testing.T) {
+	m, err := ICUToTemplate("{name} has {n, plural, =0 {no files} one {# file} other {# files '#1'}} since {d, date, yMMMd}")
+	require.NoError(t, err)
+	assert.Equal(t, &i18n.Message{
+		Zero:  "{{.name}} has no files since {{.d}}",
+		One:   "{{.name}} has {{.n}} file since {{.d}}",
+		Other: "{{.name}} has {{.n}} files #1 since {{.d}}",
+	}, m)
+
+	m, err = ICUToTemplate("Total: {amount, number, currency} '{'x'}'")
+	require.NoError(t, err)
+	assert.Equal(t, &i18n.Message{Other: "Total: {{.amount}} {x}"}, m)
+
+	for _, invalid := range []string{
+		"{g, select, male {he} other {they}}",
+		"{n, plural, one {#} other {{m, plural, one {x} other {y}}}}",
+		"{n, plural, offset:1 one {#} other {#}}",
+		"{a, plural, other {x}} {b, plural, other {y}}",
+		"{n, plural, one {x}}",
+		"unclosed {name",
+	} {
+		_, err := ICUToTemplate(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLocaleLoaderValidatesICU(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{"FILES": "{count, plural, one {# file} other {# files}}"}`,
+		"fr.json": `{"FILES": "{count, plural, one {# fichier} other {# fichiers}"}`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir, MessageFormat: ICUFormat}, i18n.NewBundle(language.English))
+	err := loader.LoadLocales()
+
+	var loadErr *LoadError
+	require.ErrorAs(t, err, &loadErr)
+	require.Len(t, loadErr.Files, 1)
+	assert.Equal(t, "fr", loadErr.Files[0].Lang)
+	assert.Contains(t, loadErr.Files[0].Error(), "message FILES")
+	assert.Len(t, loader.Messages("en"), 1)
+}
@@ -11,6 +11,7 @@ type CacheManager interface {
 	Get(key string) (string, bool)
 	Set(key, value string)
 	Delete(key string)
+	DeleteFunc(match func(key string) bool) int
 	Clear()
 	Close() error
 	GetStats() CacheStats
@@ -30,6 +31,7 @@ type PoolManager interface {
 	Get(lang string) *i18n.Localizer
 	Put(lang string, localizer *i18n.Localizer)
 	WarmUp(languages []string)
+	Reset(bundle *i18n.Bundle)
 	GetStats() PoolStats
 	Close() error
 }
@@ -1,12 +1,20 @@
 package internal
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
+	"log"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
 )
 
 // LocaleMode 语言文件组织模式
@@ -21,100 +29,488 @@ const (
 
 // LocaleLoaderConfig 语言文件加载器配置
 type LocaleLoaderConfig struct {
-	Mode      LocaleMode `yaml:"mode" json:"mode"`
+	Mode      LocaleMode `yaml:"mode" json:"mode"` // 为空时同时查找扁平和分层结构
 	Path      string     `yaml:"path" json:"path"`
 	Languages []string   `yaml:"languages" json:"languages"`
 	Modules   []string   `yaml:"modules,omitempty" json:"modules,omitempty"` // 仅在嵌套模式下使用
+	Debug     bool       `yaml:"debug" json:"debug"`
+
+	// KeySeparator 树形语言文件展开为消息ID时使用的分隔符，默认为 "."
+	KeySeparator string `yaml:"key_separator,omitempty" json:"key_separator,omitempty"`
+
+	// Namespaces 分层模式下以 "模块:消息ID" 注册消息，避免不同模块的同名消息互相覆盖
+	Namespaces bool `yaml:"namespaces" json:"namespaces"`
+
+	// MessageFormat 消息语法，为 ICUFormat 时加载时检查每条消息是否为合法的 ICU MessageFormat
+	MessageFormat MessageFormat `yaml:"message_format,omitempty" json:"message_format,omitempty"`
+
+	// FS 语言文件所在的文件系统（如 embed.FS），为空时使用操作系统文件系统
+	FS fs.FS `yaml:"-" json:"-"`
+
+	// Sources 在语言文件之后按顺序加载的其他消息来源，同一语言中的同名消息后加载的覆盖先加载的
+	Sources []MessageSource `yaml:"-" json:"-"`
+}
+
+// LocaleFile 发现的语言文件
+type LocaleFile struct {
+	Path   string `json:"path"`
+	Lang   string `json:"lang"`
+	Module string `json:"module,omitempty"`
+	Size   int64  `json:"size"`
 }
 
 // LocaleLoader 语言文件加载器
 type LocaleLoader struct {
 	config LocaleLoaderConfig
 	bundle *i18n.Bundle
+	fsys   fs.FS
+
+	mu         sync.RWMutex
+	files      []LocaleFile                           // 最近一次加载成功的文件
+	messages   map[string]map[string]*i18n.Message    // 最近一次加载的消息，按语言和消息ID索引
+	modules    map[string]map[string]string           // 最近一次加载的消息所属模块，按语言和消息ID索引
+	metadata   map[string]map[string]*MessageMetadata // 最近一次加载的消息元数据，按语言和消息ID索引
+	duplicates []DuplicateMessage                     // 最近一次加载时在多个文件中定义的消息
+	namespaces map[string][]string                    // 原始消息ID -> 定义该消息的模块（启用命名空间时）
 }
 
 // NewLocaleLoader 创建语言文件加载器
 func NewLocaleLoader(config LocaleLoaderConfig, bundle *i18n.Bundle) *LocaleLoader {
+	fsys := config.FS
+	if fsys == nil {
+		fsys = osFS{}
+	}
+	config.Path = cleanRoot(fsys, config.Path)
+
 	return &LocaleLoader{
 		config: config,
 		bundle: bundle,
+		fsys:   fsys,
 	}
 }
 
+// unmarshalFuncs 支持的语言文件格式
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"json": json.Unmarshal,
+	"yaml": yaml.Unmarshal,
+	"yml":  yaml.Unmarshal,
+	"toml": toml.Unmarshal,
+}
+
+// parseFuncs 需要知道语言才能解析的格式（复数形式依赖语言规则）
+var parseFuncs = map[string]func(buf []byte, lang string) ([]*i18n.Message, error){
+	"po": ParsePO,
+	"mo": ParseMO,
+}
+
+// localeFormats 查找语言文件时的格式优先级
+var localeFormats = []string{"json", "yaml", "yml", "toml", "po", "mo"}
+
+// RegisterUnmarshalFuncs 将支持的语言文件格式注册到 bundle
+func RegisterUnmarshalFuncs(bundle *i18n.Bundle) {
+	for format, unmarshalFunc := range unmarshalFuncs {
+		bundle.RegisterUnmarshalFunc(format, unmarshalFunc)
+	}
+}
+
+// IsLocaleFile 检查文件名是否为支持的语言文件格式
+func IsLocaleFile(name string) bool {
+	format := fileFormat(name)
+	if _, ok := unmarshalFuncs[format]; ok {
+		return true
+	}
+	_, ok := parseFuncs[format]
+	return ok
+}
+
+// fileFormat 获取文件格式（不带点的扩展名）
+func fileFormat(name string) string {
+	return strings.TrimPrefix(filepath.Ext(name), ".")
+}
+
 // LoadLocales 加载语言文件
+// 解析失败的文件不会中断加载，而是汇总到 *LoadError 中返回
 func (l *LocaleLoader) LoadLocales() error {
-	switch l.config.Mode {
-	case FlatMode:
-		return l.loadFlatStructure()
-	case NestedMode:
-		return l.loadNestedStructure()
-	default:
-		return fmt.Errorf("unsupported locale mode: %s", l.config.Mode)
+	files, err := l.DiscoverLocaleFiles()
+	if err != nil {
+		return err
 	}
-}
 
-// loadFlatStructure 加载扁平化结构
-// locales/en.json, locales/zh-CN.json
-func (l *LocaleLoader) loadFlatStructure() error {
-	for _, lang := range l.config.Languages {
-		filename := filepath.Join(l.config.Path, lang+".json")
-		if err := l.loadLocaleFile(filename, lang); err != nil {
-			return fmt.Errorf("failed to load locale file %s: %w", filename, err)
+	// 先加载通用语言再加载地区语言（pt 先于 pt-BR）：bundle 的语言匹配器在 pt 与 pt-BR 等价时选择先加入的语言，
+	// 这样 pt 和 pt-BR 都能精确匹配到自己，降级链 pt-BR -> pt 才能生效
+	sort.SliceStable(files, func(i, j int) bool {
+		return languageDepth(files[i].Lang) < languageDepth(files[j].Lang)
+	})
+
+	loadErr := &LoadError{Path: l.config.Path}
+	loaded := make([]LocaleFile, 0, len(files))
+	catalog := newMessageCatalog()
+	for _, file := range files {
+		if err := l.loadLocaleFile(file, catalog); err != nil {
+			loadErr.Files = append(loadErr.Files, FileError{
+				Path: file.Path,
+				Lang: file.Lang,
+				Err:  err,
+			})
+			if l.config.Debug {
+				log.Printf("[i18n] Failed to load %s: %v", file.Path, err)
+			}
+			continue
+		}
+
+		loaded = append(loaded, file)
+		if l.config.Debug {
+			log.Printf("[i18n] Loaded locale file: %s (%s)", file.Path, file.Lang)
+		}
+	}
+	for _, source := range l.config.Sources {
+		l.loadSource(source, catalog, loadErr)
+	}
+
+	l.mu.Lock()
+	l.files = loaded
+	l.messages = catalog.messages
+	l.modules = catalog.modules
+	l.metadata = catalog.metadata
+	l.duplicates = catalog.duplicates
+	l.namespaces = catalog.namespaces
+	l.mu.Unlock()
+
+	if l.config.Debug {
+		log.Printf("[i18n] Loaded %d locale files from %s", len(loaded), l.config.Path)
+		for _, d := range catalog.duplicates {
+			log.Printf("[i18n] Message %s (%s) is defined in multiple files: %s", d.ID, d.Lang, strings.Join(d.Files, ", "))
 		}
 	}
+
+	if len(loadErr.Files) > 0 {
+		return loadErr
+	}
+
 	return nil
 }
 
-// loadNestedStructure 加载分层结构
-// locales/en/common.json, locales/en/errors.json
-// locales/zh-CN/common.json, locales/zh-CN/errors.json
-func (l *LocaleLoader) loadNestedStructure() error {
-	// 如果没有指定模块，使用默认模块
-	modules := l.config.Modules
-	if len(modules) == 0 {
-		modules = []string{"common", "errors", "ui"}
+// languageDepth 语言代码的子标签数量，如 pt 为 1，pt-BR 为 2
+func languageDepth(lang string) int {
+	return strings.Count(strings.ReplaceAll(lang, "_", "-"), "-") + 1
+}
+
+// DiscoverLocaleFiles 遍历目录发现语言文件
+// 扁平模式: locales/en.json；分层模式: locales/en/common.json；未配置模式时两者都会查找
+func (l *LocaleLoader) DiscoverLocaleFiles() ([]LocaleFile, error) {
+	mode := l.config.Mode
+	if mode != "" && mode != FlatMode && mode != NestedMode {
+		return nil, fmt.Errorf("unsupported locale mode: %s", mode)
 	}
 
-	for _, lang := range l.config.Languages {
-		for _, module := range modules {
-			filename := filepath.Join(l.config.Path, lang, module+".json")
-			if err := l.loadLocaleFile(filename, lang); err != nil {
-				// 在嵌套模式下，允许某些模块文件不存在
-				if isFileNotExistError(err) {
-					continue // 跳过不存在的文件
-				}
-				return fmt.Errorf("failed to load locale file %s: %w", filename, err)
+	entries, err := fs.ReadDir(l.fsys, l.config.Path)
+	if err != nil {
+		if isFileNotExistError(err) {
+			if l.config.Debug {
+				log.Printf("[i18n] Locales path does not exist: %s", l.config.Path)
 			}
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to read locales path %s: %w", l.config.Path, err)
 	}
-	return nil
+
+	var files []LocaleFile
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !entry.IsDir() {
+			if mode == NestedMode || !IsLocaleFile(name) {
+				continue
+			}
+			lang := strings.TrimSuffix(name, filepath.Ext(name))
+			if l.acceptLanguage(lang) {
+				files = append(files, newLocaleFile(l.join(l.config.Path, name), lang, "", entry))
+			}
+			continue
+		}
+
+		if mode == FlatMode || !l.acceptLanguage(name) {
+			continue
+		}
+
+		langDir := l.join(l.config.Path, name)
+		moduleEntries, err := fs.ReadDir(l.fsys, langDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read language directory %s: %w", langDir, err)
+		}
+
+		for _, moduleEntry := range moduleEntries {
+			moduleName := moduleEntry.Name()
+			if moduleEntry.IsDir() || !IsLocaleFile(moduleName) {
+				continue
+			}
+			module := strings.TrimSuffix(moduleName, filepath.Ext(moduleName))
+			if l.acceptModule(module) {
+				files = append(files, newLocaleFile(l.join(langDir, moduleName), name, module, moduleEntry))
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// newLocaleFile 创建语言文件描述
+func newLocaleFile(path, lang, module string, entry fs.DirEntry) LocaleFile {
+	file := LocaleFile{Path: path, Lang: lang, Module: module}
+	if info, err := entry.Info(); err == nil {
+		file.Size = info.Size()
+	}
+	return file
 }
 
-// loadLocaleFile 加载单个语言文件
-func (l *LocaleLoader) loadLocaleFile(filename, lang string) error {
-	// 检查文件是否存在
-	if _, err := fs.Stat(nil, filename); err != nil {
+// acceptLanguage 判断语言是否需要加载
+func (l *LocaleLoader) acceptLanguage(lang string) bool {
+	if len(l.config.Languages) == 0 {
+		// 未限定语言时，只接受合法的语言代码
+		_, err := language.Parse(lang)
+		return err == nil
+	}
+
+	for _, configured := range l.config.Languages {
+		if strings.EqualFold(configured, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptModule 判断模块是否需要加载
+func (l *LocaleLoader) acceptModule(module string) bool {
+	if len(l.config.Modules) == 0 {
+		return true
+	}
+
+	for _, configured := range l.config.Modules {
+		if configured == module {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceSeparator 命名空间与消息ID之间的分隔符
+const NamespaceSeparator = ":"
+
+// DuplicateMessage 在同一语言的多个文件中定义的消息
+type DuplicateMessage struct {
+	Lang  string   `json:"lang"`
+	ID    string   `json:"id"`
+	Files []string `json:"files"`
+}
+
+// messageCatalog 加载过程中收集的消息及其所属模块
+type messageCatalog struct {
+	messages   map[string]map[string]*i18n.Message
+	modules    map[string]map[string]string
+	metadata   map[string]map[string]*MessageMetadata
+	origins    map[string]map[string]int // 语言 -> 原始消息ID -> 首次定义所在文件在 files 中的下标
+	files      []string
+	duplicates []DuplicateMessage
+	namespaces map[string][]string
+}
+
+func newMessageCatalog() *messageCatalog {
+	return &messageCatalog{
+		messages:   make(map[string]map[string]*i18n.Message),
+		modules:    make(map[string]map[string]string),
+		metadata:   make(map[string]map[string]*MessageMetadata),
+		origins:    make(map[string]map[string]int),
+		namespaces: make(map[string][]string),
+	}
+}
+
+// addNamespace 记录消息所在的命名空间
+func (c *messageCatalog) addNamespace(namespace, id string) {
+	for _, existing := range c.namespaces[id] {
+		if existing == namespace {
+			return
+		}
+	}
+	c.namespaces[id] = append(c.namespaces[id], namespace)
+}
+
+// checkDuplicates 记录在其他文件中已经定义过的消息（使用加命名空间前的原始ID）
+func (c *messageCatalog) checkDuplicates(file LocaleFile, messages []*i18n.Message) {
+	if c.origins[file.Lang] == nil {
+		c.origins[file.Lang] = make(map[string]int)
+	}
+	c.files = append(c.files, file.Path)
+
+	for _, m := range messages {
+		first, exists := c.origins[file.Lang][m.ID]
+		if !exists {
+			c.origins[file.Lang][m.ID] = len(c.files) - 1
+			continue
+		}
+
+		for i := range c.duplicates {
+			if d := &c.duplicates[i]; d.Lang == file.Lang && d.ID == m.ID {
+				d.Files = append(d.Files, file.Path)
+				exists = false
+				break
+			}
+		}
+		if exists {
+			c.duplicates = append(c.duplicates, DuplicateMessage{
+				Lang:  file.Lang,
+				ID:    m.ID,
+				Files: []string{c.files[first], file.Path},
+			})
+		}
+	}
+}
+
+// add 记录语言文件中的消息
+// metadata 为 nil 时（其他消息来源）只替换消息，保留语言文件中同名消息的模块和元数据
+func (c *messageCatalog) add(file LocaleFile, messages []*i18n.Message, metadata []*MessageMetadata) {
+	if c.messages[file.Lang] == nil {
+		c.messages[file.Lang] = make(map[string]*i18n.Message)
+		c.modules[file.Lang] = make(map[string]string)
+		c.metadata[file.Lang] = make(map[string]*MessageMetadata)
+	}
+	for i, m := range messages {
+		c.messages[file.Lang][m.ID] = m
+		if metadata == nil {
+			continue
+		}
+		c.modules[file.Lang][m.ID] = file.Module
+		if i < len(metadata) && metadata[i] != nil {
+			c.metadata[file.Lang][m.ID] = metadata[i]
+		} else {
+			delete(c.metadata[file.Lang], m.ID)
+		}
+	}
+}
+
+// loadLocaleFile 加载单个语言文件，并将消息记录到 catalog
+// 语言由调用方给出，而不是由文件名推断（分层模式下文件名是模块名）
+func (l *LocaleLoader) loadLocaleFile(file LocaleFile, catalog *messageCatalog) error {
+	messages, metadata, err := parseLocaleFileWithMetadata(l.fsys, file.Path, file.Lang, l.config.KeySeparator)
+	if err != nil {
 		return err
 	}
+	return l.addMessages(file, messages, metadata, false, catalog)
+}
+
+// addMessages 检查消息并加入 bundle 和 catalog，metadata 按下标与消息对应（见 messageCatalog.add）
+// fromSource 表示消息来自其他消息来源，这些消息有意覆盖语言文件中的同名消息，不记录为重复定义
+func (l *LocaleLoader) addMessages(file LocaleFile, messages []*i18n.Message, metadata []*MessageMetadata, fromSource bool, catalog *messageCatalog) error {
+	tag, err := language.Parse(file.Lang)
+	if err != nil {
+		return fmt.Errorf("invalid language code %q: %w", file.Lang, err)
+	}
+
+	if l.config.MessageFormat == ICUFormat {
+		for _, m := range messages {
+			if err := ValidateICUMessage(m); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !fromSource {
+		catalog.checkDuplicates(file, messages)
+	}
+	if l.config.Namespaces && file.Module != "" {
+		for _, m := range messages {
+			catalog.addNamespace(file.Module, m.ID)
+			m.ID = file.Module + NamespaceSeparator + m.ID
+		}
+	}
 
-	// 这里需要实际的文件加载逻辑
-	// 暂时返回成功，实际实现需要读取文件并解析
-	// return l.bundle.MustLoadMessageFile(filename, lang)
+	if err := l.bundle.AddMessages(tag, messages...); err != nil {
+		return err
+	}
 
+	catalog.add(file, messages, metadata)
 	return nil
 }
 
+// parseLocaleFile 使用加载器的配置解析语言文件
+func (l *LocaleLoader) parseLocaleFile(file LocaleFile) ([]*i18n.Message, error) {
+	return parseLocaleFile(l.fsys, file.Path, file.Lang, l.config.KeySeparator)
+}
+
+// parseLocaleFile 读取并解析语言文件中的消息
+func parseLocaleFile(fsys fs.FS, filename, lang, separator string) ([]*i18n.Message, error) {
+	messages, _, err := parseLocaleFileWithMetadata(fsys, filename, lang, separator)
+	return messages, err
+}
+
+// parseLocaleFileWithMetadata 读取并解析语言文件中的消息及其元数据（按下标对应，没有元数据时为 nil）
+// 对象形式的文件按树形结构展开（见 flattenMessages），数组形式的文件按 go-i18n v1 格式解析（见 parseMessageItems）
+func parseLocaleFileWithMetadata(fsys fs.FS, filename, lang, separator string) ([]*i18n.Message, []*MessageMetadata, error) {
+	buf, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	format := fileFormat(filename)
+	if parse, ok := parseFuncs[format]; ok {
+		messages, err := parse(buf, lang)
+		return messages, make([]*MessageMetadata, len(messages)), err
+	}
+
+	unmarshal, ok := unmarshalFuncs[format]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported locale file format: %s", format)
+	}
+	var raw interface{}
+	if err := unmarshal(buf, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	switch data := normalizeMap(raw).(type) {
+	case nil:
+		return nil, nil, nil
+	case map[string]interface{}:
+		return flattenMessages(data, separator)
+	case []interface{}:
+		return parseMessageItems(data)
+	}
+	return nil, nil, fmt.Errorf("unsupported locale file content: %T", raw)
+}
+
 // DetectLocaleMode 自动检测语言文件结构模式
 func DetectLocaleMode(localesPath string) (LocaleMode, error) {
-	// 检查是否存在语言目录
-	langDir := filepath.Join(localesPath, "en")
-	if dirExists(langDir) {
-		return NestedMode, nil
+	return DetectLocaleModeFS(osFS{}, localesPath)
+}
+
+// DetectLocaleModeFS 在指定文件系统中自动检测语言文件结构模式
+func DetectLocaleModeFS(fsys fs.FS, localesPath string) (LocaleMode, error) {
+	localesPath = cleanRoot(fsys, localesPath)
+	entries, err := fs.ReadDir(fsys, localesPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read locales path %s: %w", localesPath, err)
 	}
 
-	// 检查是否存在语言文件
-	langFile := filepath.Join(localesPath, "en.json")
-	if fileExists(langFile) {
+	hasFlatFile := false
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			if IsLocaleFile(entry.Name()) {
+				hasFlatFile = true
+			}
+			continue
+		}
+
+		// 检查是否存在包含语言文件的语言目录
+		moduleEntries, err := fs.ReadDir(fsys, joinPath(fsys, localesPath, entry.Name()))
+		if err != nil {
+			continue
+		}
+		for _, moduleEntry := range moduleEntries {
+			if !moduleEntry.IsDir() && IsLocaleFile(moduleEntry.Name()) {
+				return NestedMode, nil
+			}
+		}
+	}
+
+	if hasFlatFile {
 		return FlatMode, nil
 	}
 
@@ -123,46 +519,147 @@ func DetectLocaleMode(localesPath string) (LocaleMode, error) {
 
 // GetLocaleFiles 获取所有语言文件列表
 func (l *LocaleLoader) GetLocaleFiles() []string {
-	var files []string
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 
-	switch l.config.Mode {
-	case FlatMode:
-		for _, lang := range l.config.Languages {
-			files = append(files, filepath.Join(l.config.Path, lang+".json"))
+	files := make([]string, len(l.files))
+	for i, file := range l.files {
+		files[i] = file.Path
+	}
+	return files
+}
+
+// Languages 获取已加载的语言列表，语言文件中的语言在前，只存在于其他消息来源中的语言按语言代码排序在后
+func (l *LocaleLoader) Languages() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var languages []string
+	seen := make(map[string]bool)
+	for _, file := range l.files {
+		if !seen[file.Lang] {
+			seen[file.Lang] = true
+			languages = append(languages, file.Lang)
 		}
-	case NestedMode:
-		modules := l.config.Modules
-		if len(modules) == 0 {
-			modules = []string{"common", "errors", "ui"}
+	}
+
+	var others []string
+	for lang := range l.messages {
+		if !seen[lang] {
+			others = append(others, lang)
 		}
+	}
+	sort.Strings(others)
+	return append(languages, others...)
+}
 
-		for _, lang := range l.config.Languages {
-			for _, module := range modules {
-				files = append(files, filepath.Join(l.config.Path, lang, module+".json"))
-			}
+// Messages 获取最近一次加载的指定语言的消息，按消息ID排序
+func (l *LocaleLoader) Messages(lang string) []*i18n.Message {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var byID map[string]*i18n.Message
+	for loaded, messages := range l.messages {
+		if strings.EqualFold(loaded, lang) {
+			byID = messages
+			break
 		}
 	}
 
-	return files
+	messages := make([]*i18n.Message, 0, len(byID))
+	for _, m := range byID {
+		messages = append(messages, m)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+	return messages
+}
+
+// HasMessage 检查最近一次加载的任意语言中是否存在该消息
+func (l *LocaleLoader) HasMessage(id string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, messages := range l.messages {
+		if _, ok := messages[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageNamespaces 获取定义了该消息（未加命名空间的原始ID）的模块，仅在启用命名空间时有效
+func (l *LocaleLoader) MessageNamespaces(id string) []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return append([]string(nil), l.namespaces[id]...)
+}
+
+// HasModule 检查最近一次加载的文件中是否存在该模块
+func (l *LocaleLoader) HasModule(module string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, file := range l.files {
+		if file.Module == module {
+			return true
+		}
+	}
+	return false
+}
+
+// Duplicates 获取最近一次加载时在多个文件中定义的消息
+func (l *LocaleLoader) Duplicates() []DuplicateMessage {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return append([]DuplicateMessage(nil), l.duplicates...)
+}
+
+// MessageModule 获取消息所属的模块，扁平模式下为空
+func (l *LocaleLoader) MessageModule(lang, id string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for loaded, modules := range l.modules {
+		if strings.EqualFold(loaded, lang) {
+			return modules[id]
+		}
+	}
+	return ""
 }
 
 // ValidateLocaleStructure 验证语言文件结构
 func (l *LocaleLoader) ValidateLocaleStructure() error {
-	switch l.config.Mode {
+	mode := l.config.Mode
+	if mode == "" {
+		detected, err := DetectLocaleModeFS(l.fsys, l.config.Path)
+		if err != nil {
+			return err
+		}
+		mode = detected
+	}
+
+	switch mode {
 	case FlatMode:
 		return l.validateFlatStructure()
 	case NestedMode:
 		return l.validateNestedStructure()
 	}
-	return fmt.Errorf("invalid locale mode: %s", l.config.Mode)
+	return fmt.Errorf("invalid locale mode: %s", mode)
 }
 
 // validateFlatStructure 验证扁平化结构
 func (l *LocaleLoader) validateFlatStructure() error {
+	if !dirExists(l.fsys, l.config.Path) {
+		return fmt.Errorf("locales path not found: %s", l.config.Path)
+	}
+
 	for _, lang := range l.config.Languages {
-		filename := filepath.Join(l.config.Path, lang+".json")
-		if !fileExists(filename) {
-			return fmt.Errorf("locale file not found: %s", filename)
+		if l.findLocaleFile(l.config.Path, lang) == "" {
+			return fmt.Errorf("locale file not found: %s", l.join(l.config.Path, lang+".json"))
 		}
 	}
 	return nil
@@ -170,6 +667,10 @@ func (l *LocaleLoader) validateFlatStructure() error {
 
 // validateNestedStructure 验证分层结构
 func (l *LocaleLoader) validateNestedStructure() error {
+	if !dirExists(l.fsys, l.config.Path) {
+		return fmt.Errorf("locales path not found: %s", l.config.Path)
+	}
+
 	modules := l.config.Modules
 	if len(modules) == 0 {
 		modules = []string{"common", "errors", "ui"}
@@ -177,14 +678,13 @@ func (l *LocaleLoader) validateNestedStructure() error {
 
 	foundAny := false
 	for _, lang := range l.config.Languages {
-		langDir := filepath.Join(l.config.Path, lang)
-		if !dirExists(langDir) {
+		langDir := l.join(l.config.Path, lang)
+		if !dirExists(l.fsys, langDir) {
 			return fmt.Errorf("language directory not found: %s", langDir)
 		}
 
 		for _, module := range modules {
-			filename := filepath.Join(langDir, module+".json")
-			if fileExists(filename) {
+			if l.findLocaleFile(langDir, module) != "" {
 				foundAny = true
 			}
 		}
@@ -199,104 +699,99 @@ func (l *LocaleLoader) validateNestedStructure() error {
 
 // Helper functions
 
-// fileExists 检查文件是否存在
-func fileExists(filename string) bool {
-	// 实际实现需要使用 os.Stat
-	return true // 暂时返回 true
+// join 按加载器的文件系统拼接路径
+func (l *LocaleLoader) join(elem ...string) string {
+	return joinPath(l.fsys, elem...)
 }
 
-// dirExists 检查目录是否存在
-func dirExists(dirname string) bool {
-	// 实际实现需要使用 os.Stat
-	return true // 暂时返回 true
+// findLocaleFile 在目录中查找指定名称的语言文件，返回找到的路径
+func (l *LocaleLoader) findLocaleFile(dir, name string) string {
+	for _, format := range localeFormats {
+		filename := l.join(dir, name+"."+format)
+		if fileExists(l.fsys, filename) {
+			return filename
+		}
+	}
+	return ""
 }
 
 // isFileNotExistError 检查是否为文件不存在错误
 func isFileNotExistError(err error) bool {
-	// 实际实现需要检查错误类型
-	return strings.Contains(err.Error(), "no such file")
+	return errors.Is(err, fs.ErrNotExist)
 }
 
-// LocaleFileStats 语言文件统计信息
-type LocaleFileStats struct {
-	Mode      LocaleMode `json:"mode"`
-	TotalFiles int        `json:"total_files"`
-	Languages []string   `json:"languages"`
-	Modules   []string   `json:"modules,omitempty"`
-	FileSizes map[string]int64 `json:"file_sizes"`
+// FileError 单个语言文件的加载错误
+type FileError struct {
+	Path string
+	Lang string
+	Err  error
 }
 
-// GetStats 获取语言文件统计信息
-func (l *LocaleLoader) GetStats() LocaleFileStats {
-	stats := LocaleFileStats{
-		Mode:      l.config.Mode,
-		Languages: l.config.Languages,
-		FileSizes: make(map[string]int64),
-	}
+// Error 实现 error 接口
+func (e FileError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Path, e.Lang, e.Err)
+}
 
-	files := l.GetLocaleFiles()
-	stats.TotalFiles = len(files)
+// Unwrap 解包错误
+func (e FileError) Unwrap() error {
+	return e.Err
+}
 
-	if l.config.Mode == NestedMode {
-		stats.Modules = l.config.Modules
-		if len(stats.Modules) == 0 {
-			stats.Modules = []string{"common", "errors", "ui"}
-		}
-	}
+// LoadError 语言文件加载错误，列出所有加载失败的文件
+type LoadError struct {
+	Path  string
+	Files []FileError
+}
 
-	// 实际实现需要获取文件大小
-	for _, file := range files {
-		if fileExists(file) {
-			stats.FileSizes[file] = 0 // 实际需要获取真实大小
-		}
+// Error 实现 error 接口
+func (e *LoadError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to load %d locale file(s) from %s", len(e.Files), e.Path)
+	for _, f := range e.Files {
+		b.WriteString("\n  ")
+		b.WriteString(f.Error())
 	}
-
-	return stats
+	return b.String()
 }
 
-// MigrationConfig 迁移配置
-type MigrationConfig struct {
-	FromMode LocaleMode `yaml:"from_mode" json:"from_mode"`
-	ToMode   LocaleMode `yaml:"to_mode" json:"to_mode"`
-	Modules  []string   `yaml:"modules,omitempty" json:"modules,omitempty"`
+// LocaleFileStats 语言文件统计信息
+type LocaleFileStats struct {
+	Mode       LocaleMode       `json:"mode"`
+	TotalFiles int              `json:"total_files"`
+	Languages  []string         `json:"languages"`
+	Modules    []string         `json:"modules,omitempty"`
+	FileSizes  map[string]int64 `json:"file_sizes"`
+
+	// Duplicates 在多个文件中定义的消息；未启用命名空间时后加载的定义会覆盖先前的定义
+	// 其他消息来源（Config.MessageSources）中的消息有意覆盖语言文件，不计入
+	Duplicates []DuplicateMessage `json:"duplicates,omitempty"`
 }
 
-// MigrateLocaleStructure 迁移语言文件结构
-func (l *LocaleLoader) MigrateLocaleStructure(config MigrationConfig) error {
-	if config.FromMode == config.ToMode {
-		return fmt.Errorf("source and target modes are the same")
-	}
+// GetStats 获取语言文件统计信息（基于最近一次加载的文件）
+func (l *LocaleLoader) GetStats() LocaleFileStats {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 
-	switch config.ToMode {
-	case FlatMode:
-		return l.migrateToFlat(config)
-	case NestedMode:
-		return l.migrateToNested(config)
-	default:
-		return fmt.Errorf("unsupported target mode: %s", config.ToMode)
+	stats := LocaleFileStats{
+		Mode:       l.config.Mode,
+		TotalFiles: len(l.files),
+		FileSizes:  make(map[string]int64, len(l.files)),
+		Duplicates: append([]DuplicateMessage(nil), l.duplicates...),
 	}
-}
 
-// migrateToFlat 迁移到扁平化结构
-func (l *LocaleLoader) migrateToFlat(config MigrationConfig) error {
-	// 实现合并多个模块文件到单个语言文件的逻辑
-	// 这是一个复杂的操作，需要：
-	// 1. 读取所有模块文件
-	// 2. 合并翻译内容
-	// 3. 写入单个语言文件
-	// 4. 删除原模块文件（可选）
+	seenLangs := make(map[string]bool)
+	seenModules := make(map[string]bool)
+	for _, file := range l.files {
+		stats.FileSizes[file.Path] = file.Size
+		if !seenLangs[file.Lang] {
+			seenLangs[file.Lang] = true
+			stats.Languages = append(stats.Languages, file.Lang)
+		}
+		if file.Module != "" && !seenModules[file.Module] {
+			seenModules[file.Module] = true
+			stats.Modules = append(stats.Modules, file.Module)
+		}
+	}
 
-	return fmt.Errorf("migration to flat mode not implemented yet")
+	return stats
 }
-
-// migrateToNested 迁移到分层结构
-func (l *LocaleLoader) migrateToNested(config MigrationConfig) error {
-	// 实现拆分单个语言文件到多个模块文件的逻辑
-	// 这是一个复杂的操作，需要：
-	// 1. 读取单个语言文件
-	// 2. 根据ID前缀或其他规则分类翻译内容
-	// 3. 写入多个模块文件
-	// 4. 删除原语言文件（可选）
-
-	return fmt.Errorf("migration to nested mode not implemented yet")
-}
\ No newline at end of file
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+// localize 使用 bundle 翻译消息，找不到时返回消息ID
+func localize(bundle *i18n.Bundle, lang, id string) string {
+	msg, err := i18n.NewLocalizer(bundle, lang).Localize(&i18n.LocalizeConfig{MessageID: id})
+	if err != nil {
+		return id
+	}
+	return msg
+}
+
+func TestLocaleLoaderFlatDiscovery(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json":    `[{"id": "WELCOME", "translation": "Welcome"}]`,
+		"pt-BR.json": `[{"id": "WELCOME", "translation": "Bem-vindo"}]`,
+		"id.json":    `[{"id": "WELCOME", "translation": "Selamat datang"}]`,
+		"notes.txt":  `ignored`,
+	})
+
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, bundle)
+	require.NoError(t, loader.LoadLocales())
+
+	assert.Len(t, loader.GetLocaleFiles(), 3)
+	assert.Equal(t, "Bem-vindo", localize(bundle, "pt-BR", "WELCOME"))
+	assert.Equal(t, "Selamat datang", localize(bundle, "id", "WELCOME"))
+}
+
+func TestLocaleLoaderHonoursConfig(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json":           `[{"id": "FLAT", "translation": "flat"}]`,
+		"en/common.json":    `[{"id": "WELCOME", "translation": "Welcome"}]`,
+		"en/emails.json":    `[{"id": "SUBJECT", "translation": "Subject"}]`,
+		"fr/common.json":    `[{"id": "WELCOME", "translation": "Bienvenue"}]`,
+		"zh-CN/common.json": `[{"id": "WELCOME", "translation": "欢迎"}]`,
+	})
+
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{
+		Mode:      NestedMode,
+		Path:      dir,
+		Languages: []string{"en", "zh-CN"},
+		Modules:   []string{"common"},
+	}, bundle)
+	require.NoError(t, loader.LoadLocales())
+
+	assert.Equal(t, "欢迎", localize(bundle, "zh-CN", "WELCOME"))
+	assert.Equal(t, "Welcome", localize(bundle, "fr", "WELCOME"))
+	assert.Equal(t, "SUBJECT", localize(bundle, "en", "SUBJECT"))
+	assert.Equal(t, "FLAT", localize(bundle, "en", "FLAT"))
+}
+
+func TestLocaleLoaderNested(t *testing.T) {
+	common := `[{"id": "WELCOME", "translation": "Welcome"}]`
+	dir := writeFiles(t, map[string]string{
+		"en/common.json":    common,
+		"en/errors.json":    `[{"id": "NOT_FOUND", "translation": "Not found"}]`,
+		"zh-CN/common.json": `[{"id": "WELCOME", "translation": "欢迎"}]`,
+	})
+
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{
+		Mode:      NestedMode,
+		Path:      dir,
+		Languages: []string{"en", "zh-CN"},
+		Modules:   []string{"common", "errors"},
+	}, bundle)
+
+	require.NoError(t, loader.LoadLocales())
+	assert.NoError(t, loader.ValidateLocaleStructure())
+
+	loc := i18n.NewLocalizer(bundle, "zh-CN")
+	msg, err := loc.Localize(&i18n.LocalizeConfig{MessageID: "WELCOME"})
+	require.NoError(t, err)
+	assert.Equal(t, "欢迎", msg)
+
+	stats := loader.GetStats()
+	assert.Equal(t, 3, stats.TotalFiles)
+	assert.ElementsMatch(t, []string{"en", "zh-CN"}, stats.Languages)
+	assert.ElementsMatch(t, []string{"common", "errors"}, stats.Modules)
+	assert.Equal(t, int64(len(common)), stats.FileSizes[filepath.Join(dir, "en", "common.json")])
+
+	mode, err := DetectLocaleMode(dir)
+	require.NoError(t, err)
+	assert.Equal(t, NestedMode, mode)
+}
+
+func TestLocaleLoaderValidateMissingFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `[{"id": "WELCOME", "translation": "Welcome"}]`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{
+		Mode:      FlatMode,
+		Path:      dir,
+		Languages: []string{"en", "ja"},
+	}, i18n.NewBundle(language.English))
+
+	err := loader.ValidateLocaleStructure()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ja.json")
+
+	missing := NewLocaleLoader(LocaleLoaderConfig{
+		Mode: FlatMode,
+		Path: filepath.Join(dir, "missing"),
+	}, i18n.NewBundle(language.English))
+	assert.Error(t, missing.ValidateLocaleStructure())
+}
+
+func TestLocaleLoaderReportsParseErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `[{"id": "WELCOME", "translation": "Welcome"}]`,
+		"fr.json": `{not json`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, i18n.NewBundle(language.English))
+	err := loader.LoadLocales()
+
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	require.Len(t, loadErr.Files, 1)
+	assert.Equal(t, "fr", loadErr.Files[0].Lang)
+	assert.Equal(t, []string{"en"}, loader.Languages())
+}
+
+func TestLocaleLoaderFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en/common.json": {Data: []byte(`[{"id": "WELCOME", "translation": "Welcome"}]`)},
+		"locales/fr/common.json": {Data: []byte(`[{"id": "WELCOME", "translation": "Bienvenue"}]`)},
+	}
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{
+		Mode:      NestedMode,
+		Path:      "./locales",
+		Languages: []string{"en", "fr"},
+		Modules:   []string{"common"},
+		FS:        fsys,
+	}, i18n.NewBundle(language.English))
+
+	require.NoError(t, loader.LoadLocales())
+	assert.NoError(t, loader.ValidateLocaleStructure())
+	assert.Equal(t, []string{"locales/en/common.json", "locales/fr/common.json"}, loader.GetLocaleFiles())
+
+	mode, err := DetectLocaleModeFS(fsys, "locales")
+	require.NoError(t, err)
+	assert.Equal(t, NestedMode, mode)
+
+	_, err = loader.MigrateLocaleStructure(MigrationConfig{FromMode: NestedMode, ToMode: FlatMode})
+	assert.Error(t, err)
+}
+
+func TestLocaleLoaderYAMLAndTOML(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.yaml": `
+- id: WELCOME
+  translation: Welcome
+- id: FILES
+  one: "{{.Count}} file"
+  other: "{{.Count}} files"
+`,
+		"de.yml": `
+WELCOME: Willkommen
+FILES:
+  one: "{{.Count}} Datei"
+  other: "{{.Count}} Dateien"
+`,
+		"fr.toml": `
+WELCOME = "Bienvenue"
+
+[FILES]
+one = "{{.Count}} fichier"
+other = "{{.Count}} fichiers"
+`,
+	})
+
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, bundle)
+	require.NoError(t, loader.LoadLocales())
+	assert.ElementsMatch(t, []string{"en", "de", "fr"}, loader.Languages())
+
+	cases := map[string][2]string{
+		"en": {"Welcome", "2 files"},
+		"de": {"Willkommen", "1 Datei"},
+		"fr": {"Bienvenue", "1 fichier"},
+	}
+	for lang, expected := range cases {
+		loc := i18n.NewLocalizer(bundle, lang)
+		welcome, err := loc.Localize(&i18n.LocalizeConfig{MessageID: "WELCOME"})
+		require.NoError(t, err, lang)
+		assert.Equal(t, expected[0], welcome)
+
+		count := 1
+		if lang == "en" {
+			count = 2
+		}
+		files, err := loc.Localize(&i18n.LocalizeConfig{
+			MessageID:    "FILES",
+			PluralCount:  count,
+			TemplateData: map[string]interface{}{"Count": count},
+		})
+		require.NoError(t, err, lang)
+		assert.Equal(t, expected[1], files)
+	}
+}
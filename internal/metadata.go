@@ -0,0 +1,473 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template/parse"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// MessageMetadata 写在语言文件消息对象中的元数据，不参与翻译
+//
+//	{"WELCOME": {"other": "Welcome, {{.Name}}", "description": "首页标题",
+//	             "max_length": 30, "placeholders": ["Name"], "owner": "growth"}}
+type MessageMetadata struct {
+	MaxLength    int      `json:"max_length,omitempty"`   // 每个复数形式文本的最大字符数，不计占位符
+	Placeholders []string `json:"placeholders,omitempty"` // 允许使用的模板数据字段或 ICU 参数
+	Owner        string   `json:"owner,omitempty"`        // 负责的团队或模块
+}
+
+// metadataFields 元数据字段（小写）
+var metadataFields = map[string]bool{
+	"max_length":   true,
+	"placeholders": true,
+	"owner":        true,
+}
+
+// extractMetadata 从消息对象中取出并删除元数据字段，没有元数据时返回 nil
+func extractMetadata(fields map[string]interface{}) (*MessageMetadata, error) {
+	var meta *MessageMetadata
+	for key, value := range fields {
+		name := strings.ToLower(key)
+		if !metadataFields[name] {
+			continue
+		}
+		delete(fields, key)
+		if meta == nil {
+			meta = &MessageMetadata{}
+		}
+
+		switch name {
+		case "max_length":
+			n, err := icuNumber(value)
+			if err != nil || n < 0 || n != float64(int(n)) {
+				return nil, fmt.Errorf("max_length must be a non-negative integer, got %v", value)
+			}
+			meta.MaxLength = int(n)
+		case "placeholders":
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("placeholders must be a list, got %T", value)
+			}
+			for _, item := range items {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("placeholders must be strings, got %T", item)
+				}
+				meta.Placeholders = append(meta.Placeholders, strings.TrimPrefix(s, "."))
+			}
+		case "owner":
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("owner must be a string, got %T", value)
+			}
+			meta.Owner = s
+		}
+	}
+	return meta, nil
+}
+
+// parseMessageItems 解析数组形式（go-i18n v1 格式）的语言文件，每一项为一条消息
+// 项中的 context 字段合并到消息ID（见 messageContext），元数据按下标与消息对应返回
+func parseMessageItems(items []interface{}) ([]*i18n.Message, []*MessageMetadata, error) {
+	messages := make([]*i18n.Message, 0, len(items))
+	metadata := make([]*MessageMetadata, 0, len(items))
+	for i, item := range items {
+		fields, ok := normalizeMap(item).(map[string]interface{})
+		if !ok {
+			m, err := i18n.NewMessage(item)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			messages = append(messages, m)
+			metadata = append(metadata, nil)
+			continue
+		}
+
+		variant, err := messageContext(fields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message %v: %w", fields["id"], err)
+		}
+		meta, err := extractMetadata(fields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message %v: %w", fields["id"], err)
+		}
+		m, err := i18n.NewMessage(fields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message %v: %w", fields["id"], err)
+		}
+		m.ID = VariantID(m.ID, variant)
+
+		messages = append(messages, m)
+		metadata = append(metadata, meta)
+	}
+	return messages, metadata, nil
+}
+
+// TemplatePlaceholders 返回 Go 模板中引用的数据字段，.Name 和 .User.Name 分别记为 Name 和 User，按出现顺序去重
+// 模板函数不做检查，因此 {{num .Count}} 等自定义函数不会导致解析失败
+func TemplatePlaceholders(src, leftDelim, rightDelim string) ([]string, error) {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	if !strings.Contains(src, leftDelim) {
+		return nil, nil
+	}
+
+	tree := parse.New("message")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(src, leftDelim, rightDelim, make(map[string]*parse.Tree)); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	collectFields(tree.Root, &names)
+	return names, nil
+}
+
+// collectFields 遍历模板语法树收集数据字段
+func collectFields(node parse.Node, names *[]string) {
+	add := func(name string) {
+		if !containsString(*names, name) {
+			*names = append(*names, name)
+		}
+	}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, names)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, names)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, names)
+		}
+	case *parse.FieldNode:
+		add(n.Ident[0])
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			add(n.Ident[1])
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, names)
+	case *parse.IfNode:
+		collectBranch(&n.BranchNode, names)
+	case *parse.RangeNode:
+		collectBranch(&n.BranchNode, names)
+	case *parse.WithNode:
+		collectBranch(&n.BranchNode, names)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, names)
+	}
+}
+
+func collectBranch(n *parse.BranchNode, names *[]string) {
+	collectFields(n.Pipe, names)
+	collectFields(n.List, names)
+	collectFields(n.ElseList, names)
+}
+
+// textLength 返回消息一个复数形式中文本的字符数，模板动作（{{.Name}} 等）和 ICU 参数不计入，
+// 条件分支以及复数、选择的子消息取最长的一个；无法解析时按原文计算
+func textLength(src string, m *i18n.Message, format MessageFormat) int {
+	if format == ICUFormat {
+		if msg, err := ParseICU(src); err == nil {
+			return icuTextLength(msg.nodes)
+		}
+		return utf8.RuneCountInString(src)
+	}
+
+	leftDelim, rightDelim := m.LeftDelim, m.RightDelim
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	tree := parse.New("message")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(src, leftDelim, rightDelim, make(map[string]*parse.Tree)); err != nil {
+		return utf8.RuneCountInString(src)
+	}
+	return templateTextLength(tree.Root)
+}
+
+// templateTextLength 模板语法树中文本节点的字符数
+func templateTextLength(node parse.Node) int {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return 0
+		}
+		total := 0
+		for _, child := range n.Nodes {
+			total += templateTextLength(child)
+		}
+		return total
+	case *parse.TextNode:
+		return utf8.RuneCount(n.Text)
+	case *parse.IfNode:
+		return max(templateTextLength(n.List), templateTextLength(n.ElseList))
+	case *parse.RangeNode:
+		return max(templateTextLength(n.List), templateTextLength(n.ElseList))
+	case *parse.WithNode:
+		return max(templateTextLength(n.List), templateTextLength(n.ElseList))
+	}
+	return 0
+}
+
+// icuTextLength ICU 消息片段中文本的字符数
+func icuTextLength(nodes []icuNode) int {
+	total := 0
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case icuText:
+			total += utf8.RuneCountInString(string(n))
+		case *icuArg:
+			longest := 0
+			for _, sub := range n.cases {
+				longest = max(longest, icuTextLength(sub))
+			}
+			total += longest
+		}
+	}
+	return total
+}
+
+// messagePlaceholders 返回消息所有复数形式中使用的占位符
+func messagePlaceholders(m *i18n.Message, format MessageFormat) []string {
+	var names []string
+	for _, form := range MessageForms(m) {
+		var found []string
+		if format == ICUFormat {
+			if msg, err := ParseICU(form.Value); err == nil {
+				found = msg.Arguments()
+			}
+		} else {
+			found, _ = TemplatePlaceholders(form.Value, m.LeftDelim, m.RightDelim)
+		}
+		for _, name := range found {
+			if !containsString(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// MessageInfo 消息的元数据
+// Module 和 Description 优先取源语言（默认语言）中的值，源语言没有时取其他语言中按语言代码排序的第一个值；
+// MaxLength、Placeholders 和 Owner 只取自源语言，翻译中的声明不生效
+type MessageInfo struct {
+	ID           string   `json:"id"`
+	Module       string   `json:"module,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	MaxLength    int      `json:"max_length,omitempty"`
+	Placeholders []string `json:"placeholders,omitempty"` // 声明的占位符，未声明时为源语言消息中使用的占位符
+	Owner        string   `json:"owner,omitempty"`
+	Languages    []string `json:"languages"`           // 语言文件中包含该消息的语言
+	Overrides    []string `json:"overrides,omitempty"` // 运行时覆盖了该消息的语言
+}
+
+// IssueKind 消息校验问题的类型
+type IssueKind string
+
+const (
+	IssueMaxLength   IssueKind = "max_length"  // 超过 max_length
+	IssuePlaceholder IssueKind = "placeholder" // 使用了源语言未声明的占位符
+
+	IssueTemplate            IssueKind = "template"             // 模板无法解析
+	IssuePlaceholderMismatch IssueKind = "placeholder_mismatch" // 占位符集合与源语言不同
+
+	IssueDeclaredPlaceholders IssueKind = "declared_placeholders" // 翻译中声明了占位符（只有源语言的声明生效）
+)
+
+// MessageIssue 消息校验发现的问题
+type MessageIssue struct {
+	Lang    string    `json:"lang"`
+	ID      string    `json:"id"`
+	Kind    IssueKind `json:"kind"`
+	Message string    `json:"message"`
+}
+
+// Error 实现 error 接口
+func (i MessageIssue) Error() string {
+	return fmt.Sprintf("%s (%s): %s", i.ID, i.Lang, i.Message)
+}
+
+// MessageInfos 返回最近一次加载的所有消息的元数据，按消息ID排序，source 为源语言
+func (l *LocaleLoader) MessageInfos(source string) []MessageInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ids := make(map[string]bool)
+	for _, messages := range l.messages {
+		for id := range messages {
+			ids[id] = true
+		}
+	}
+
+	infos := make([]MessageInfo, 0, len(ids))
+	for id := range ids {
+		infos = append(infos, l.messageInfo(source, id))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// MessageInfo 返回消息的元数据，消息不存在时返回 false
+func (l *LocaleLoader) MessageInfo(source, id string) (MessageInfo, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	info := l.messageInfo(source, id)
+	return info, len(info.Languages) > 0
+}
+
+// messageInfo 合并各语言中的元数据，调用方需持有读锁
+func (l *LocaleLoader) messageInfo(source, id string) MessageInfo {
+	info := MessageInfo{ID: id, Languages: []string{}}
+	for _, lang := range l.sourceFirstLanguages(source) {
+		m, ok := l.messages[lang][id]
+		if !ok {
+			continue
+		}
+		info.Languages = append(info.Languages, lang)
+
+		if info.Module == "" {
+			info.Module = l.modules[lang][id]
+		}
+		if info.Description == "" {
+			info.Description = m.Description
+		}
+		// 翻译不能放宽源语言的限制
+		if meta := l.metadata[lang][id]; meta != nil && strings.EqualFold(lang, source) {
+			info.MaxLength = meta.MaxLength
+			info.Placeholders = meta.Placeholders
+			info.Owner = meta.Owner
+		}
+	}
+
+	if info.Placeholders == nil {
+		if m := l.sourceMessage(source, id); m != nil {
+			info.Placeholders = append([]string{}, messagePlaceholders(m, l.config.MessageFormat)...)
+		}
+	}
+	sort.Strings(info.Languages)
+	return info
+}
+
+// sourceFirstLanguages 已加载的语言，源语言在前，其余按语言代码排序
+func (l *LocaleLoader) sourceFirstLanguages(source string) []string {
+	languages := make([]string, 0, len(l.messages))
+	for lang := range l.messages {
+		languages = append(languages, lang)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		si, sj := strings.EqualFold(languages[i], source), strings.EqualFold(languages[j], source)
+		if si != sj {
+			return si
+		}
+		return languages[i] < languages[j]
+	})
+	return languages
+}
+
+// sourceMessage 返回源语言中的消息，调用方需持有读锁
+func (l *LocaleLoader) sourceMessage(source, id string) *i18n.Message {
+	for lang, messages := range l.messages {
+		if strings.EqualFold(lang, source) {
+			return messages[id]
+		}
+	}
+	return nil
+}
+
+// ValidateMessages 按元数据校验最近一次加载的消息，source 为源语言：
+// 每个复数形式的文本不能超过 max_length 个字符（占位符和模板动作不计入，分支取最长的一个）；
+// 只能使用源语言声明的占位符，未声明时只能使用源语言消息中使用的占位符；翻译不能声明占位符
+// 问题按消息ID和语言排序
+func (l *LocaleLoader) ValidateMessages(source string) []MessageIssue {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var issues []MessageIssue
+	infos := make(map[string]MessageInfo)
+	for _, lang := range l.sourceFirstLanguages(source) {
+		for id, m := range l.messages[lang] {
+			info, ok := infos[id]
+			if !ok {
+				info = l.messageInfo(source, id)
+				infos[id] = info
+			}
+			issues = append(issues, l.validateMessage(lang, m, info)...)
+			if meta := l.metadata[lang][id]; meta != nil && meta.Placeholders != nil && !strings.EqualFold(lang, source) {
+				issues = append(issues, MessageIssue{
+					Lang:    lang,
+					ID:      id,
+					Kind:    IssueDeclaredPlaceholders,
+					Message: "placeholders can only be declared in the source language",
+				})
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].ID != issues[j].ID {
+			return issues[i].ID < issues[j].ID
+		}
+		return issues[i].Lang < issues[j].Lang
+	})
+	return issues
+}
+
+// validateMessage 校验一种语言中的消息
+func (l *LocaleLoader) validateMessage(lang string, m *i18n.Message, info MessageInfo) []MessageIssue {
+	var issues []MessageIssue
+	if info.MaxLength > 0 {
+		for _, form := range MessageForms(m) {
+			if n := textLength(form.Value, m, l.config.MessageFormat); n > info.MaxLength {
+				issues = append(issues, MessageIssue{
+					Lang:    lang,
+					ID:      m.ID,
+					Kind:    IssueMaxLength,
+					Message: fmt.Sprintf("%s form has %d characters, max_length is %d", form.Name, n, info.MaxLength),
+				})
+			}
+		}
+	}
+
+	// 没有声明占位符且源语言中没有该消息时不检查
+	if info.Placeholders != nil {
+		for _, name := range messagePlaceholders(m, l.config.MessageFormat) {
+			if !containsString(info.Placeholders, name) {
+				issues = append(issues, MessageIssue{
+					Lang:    lang,
+					ID:      m.ID,
+					Kind:    IssuePlaceholder,
+					Message: fmt.Sprintf("placeholder %s is not declared in the source language", name),
+				})
+			}
+		}
+	}
+	return issues
+}
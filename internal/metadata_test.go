@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestTemplatePlaceholders(t *testing.T) {
+	names, err := TemplatePlaceholders(`Hi {{.Name}}, {{num .Count}} {{if .User.Admin}}admin{{else}}{{$.Role}}{{end}} {{.Name}}`, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Count", "User", "Role"}, names)
+
+	names, err = TemplatePlaceholders("Hi <<.Name>>", "<<", ">>")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name"}, names)
+
+	_, err = TemplatePlaceholders("Hi {{.Name", "", "")
+	assert.Error(t, err)
+}
+
+func TestLoadMessageMetadata(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{
+			"WELCOME": {"other": "Welcome, {{.Name}}", "description": "Home title", "max_length": 20, "owner": "growth"},
+			"FILES": {"one": "{{.Count}} file", "other": "{{.Count}} files", "placeholders": ["Count"]},
+			"PLAIN": "Plain",
+			"GREETING": "Hello"
+		}`,
+		"de.yaml": "WELCOME:\n  other: Willkommen zurück auf unserer Seite, {{.Name}}\nFILES:\n  one: \"{{.Count}} Datei\"\n  other: \"{{.Num}} Dateien\"\nPLAIN: \"{{.Name}}\"\n",
+		"fr.json": `[{"id": "WELCOME", "translation": "Bienvenue, {{.Nom}}", "owner": "fr-team"}, {"id": "WELCOME", "context": "female", "translation": "Bienvenue"}, {"id": "GREETING", "translation": "Bonjour {{.Nom}}", "placeholders": ["Nom"], "max_length": 5}]`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+
+	info, ok := loader.MessageInfo("en", "WELCOME")
+	require.True(t, ok)
+	assert.Equal(t, MessageInfo{
+		ID:           "WELCOME",
+		Description:  "Home title",
+		MaxLength:    20,
+		Placeholders: []string{"Name"},
+		Owner:        "growth",
+		Languages:    []string{"de", "en", "fr"},
+	}, info)
+
+	info, ok = loader.MessageInfo("en", "FILES")
+	require.True(t, ok)
+	assert.Equal(t, []string{"Count"}, info.Placeholders)
+
+	// 翻译中声明的元数据不生效
+	info, ok = loader.MessageInfo("en", "GREETING")
+	require.True(t, ok)
+	assert.Equal(t, []string{}, info.Placeholders)
+	assert.Zero(t, info.MaxLength)
+
+	_, ok = loader.MessageInfo("en", "MISSING")
+	assert.False(t, ok)
+	assert.Len(t, loader.MessageInfos("en"), 5) // 包括 WELCOME#female
+
+	var got []string
+	for _, issue := range loader.ValidateMessages("en") {
+		got = append(got, string(issue.Kind)+" "+issue.ID+" "+issue.Lang)
+	}
+	assert.Equal(t, []string{
+		"placeholder FILES de",
+		"placeholder GREETING fr",
+		"declared_placeholders GREETING fr",
+		"placeholder PLAIN de",
+		"max_length WELCOME de",
+		"placeholder WELCOME fr",
+	}, got)
+}
+
+func TestValidateMessagesMaxLengthCountsText(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{
+			"TITLE": {"other": "Hi, {{.Name}}!", "max_length": 5},
+			"STATUS": {"other": "{{if .Online}}Online{{else}}Away{{end}}", "max_length": 6}
+		}`,
+		"de.json": `{"TITLE": "Hallo, {{.Name}}!", "STATUS": "{{if .Online}}Online{{else}}Abwesend{{end}}"}`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+	issues := loader.ValidateMessages("en")
+	require.Len(t, issues, 2)
+	assert.EqualError(t, issues[0], "STATUS (de): other form has 8 characters, max_length is 6")
+	assert.EqualError(t, issues[1], "TITLE (de): other form has 8 characters, max_length is 5")
+
+	dir = writeFiles(t, map[string]string{
+		"en.json": `{"FILES": {"other": "{n, plural, one {# file} other {# files}}", "max_length": 6}}`,
+		"fr.json": `{"FILES": "{n, plural, one {# fichier} other {# fichiers}}"}`,
+	})
+	loader = NewLocaleLoader(LocaleLoaderConfig{Path: dir, MessageFormat: ICUFormat}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+	issues = loader.ValidateMessages("en")
+	require.Len(t, issues, 1)
+	assert.EqualError(t, issues[0], "FILES (fr): other form has 9 characters, max_length is 6")
+}
+
+func TestMessageMetadataErrors(t *testing.T) {
+	for _, content := range []string{
+		`{"A": {"other": "a", "max_length": "ten"}}`,
+		`{"A": {"other": "a", "placeholders": "Name"}}`,
+		`[{"id": "A", "translation": "a", "owner": 1}]`,
+	} {
+		dir := writeFiles(t, map[string]string{"en.json": content})
+		loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, i18n.NewBundle(language.English))
+		assert.Error(t, loader.LoadLocales(), content)
+	}
+}
@@ -0,0 +1,466 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// MigrationConfig 迁移配置
+type MigrationConfig struct {
+	FromMode LocaleMode `yaml:"from_mode" json:"from_mode"`
+	ToMode   LocaleMode `yaml:"to_mode" json:"to_mode"`
+	Modules  []string   `yaml:"modules,omitempty" json:"modules,omitempty"`
+
+	// ModuleMapping 显式的模块映射: 模块名 -> 消息ID列表，以 * 结尾的条目按前缀匹配
+	ModuleMapping map[string][]string `yaml:"module_mapping,omitempty" json:"module_mapping,omitempty"`
+
+	// DefaultModule 无法归类的消息写入的模块，默认为 common
+	DefaultModule string `yaml:"default_module,omitempty" json:"default_module,omitempty"`
+
+	// DryRun 只计算迁移计划，不修改任何文件
+	DryRun bool `yaml:"dry_run" json:"dry_run"`
+
+	// BackupDir 原文件的备份目录，为空时不备份
+	BackupDir string `yaml:"backup_dir,omitempty" json:"backup_dir,omitempty"`
+}
+
+// MigrationResult 迁移结果
+type MigrationResult struct {
+	Written   []string            `json:"written"`
+	Removed   []string            `json:"removed"`
+	BackupDir string              `json:"backup_dir,omitempty"`
+	Conflicts []MigrationConflict `json:"conflicts,omitempty"`
+}
+
+// MigrationConflict 同一语言下多个文件对同一消息ID的定义不一致
+// 迁移时保留第一个定义（Files[0]）
+type MigrationConflict struct {
+	Lang  string   `json:"lang"`
+	ID    string   `json:"id"`
+	Files []string `json:"files"`
+}
+
+// String 返回冲突描述
+func (c MigrationConflict) String() string {
+	return fmt.Sprintf("[%s] %s defined differently in %s", c.Lang, c.ID, strings.Join(c.Files, ", "))
+}
+
+// plannedFile 迁移计划中待写入的文件
+type plannedFile struct {
+	path     string
+	format   string
+	tree     bool // 与源文件相同写为对象形式（树形或以消息ID为键）
+	messages []*i18n.Message
+	metadata []*MessageMetadata // 按下标与 messages 对应
+}
+
+// MigrateLocaleStructure 迁移语言文件结构，新文件保持源文件的格式、组织形式（数组或树形）和消息元数据
+func (l *LocaleLoader) MigrateLocaleStructure(config MigrationConfig) (*MigrationResult, error) {
+	if !isOSFS(l.fsys) {
+		return nil, fmt.Errorf("migration requires locale files on the OS filesystem")
+	}
+
+	if config.FromMode == config.ToMode {
+		return nil, fmt.Errorf("source and target modes are the same")
+	}
+
+	switch config.ToMode {
+	case FlatMode:
+		return l.migrateToFlat(config)
+	case NestedMode:
+		return l.migrateToNested(config)
+	default:
+		return nil, fmt.Errorf("unsupported target mode: %s", config.ToMode)
+	}
+}
+
+// migrateToFlat 迁移到扁平化结构
+// 将 locales/en/*.json 合并为 locales/en.json
+func (l *LocaleLoader) migrateToFlat(config MigrationConfig) (*MigrationResult, error) {
+	files, err := l.sourceFiles(NestedMode)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{}
+	var planned []plannedFile
+	for _, group := range groupByLanguage(files) {
+		lang := group[0].Lang
+		format := fileFormat(group[0].Path)
+
+		var merged []*i18n.Message
+		var metadata []*MessageMetadata
+		index := make(map[string]int)
+		sources := make(map[string]string)
+		tree := true
+		for _, file := range group {
+			messages, fileMetadata, err := parseLocaleFileWithMetadata(l.fsys, file.Path, file.Lang, l.config.KeySeparator)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file.Path, err)
+			}
+			isTree, err := isTreeFile(l.fsys, file.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file.Path, err)
+			}
+			tree = tree && isTree
+
+			for i, m := range messages {
+				if i, exists := index[m.ID]; exists {
+					if !sameTranslation(merged[i], m) {
+						result.Conflicts = append(result.Conflicts, MigrationConflict{
+							Lang:  lang,
+							ID:    m.ID,
+							Files: []string{sources[m.ID], file.Path},
+						})
+					}
+					continue
+				}
+				index[m.ID] = len(merged)
+				sources[m.ID] = file.Path
+				merged = append(merged, m)
+				metadata = append(metadata, metadataAt(fileMetadata, i))
+			}
+			result.Removed = append(result.Removed, file.Path)
+		}
+
+		planned = append(planned, plannedFile{
+			path:     filepath.Join(l.config.Path, lang+"."+format),
+			format:   format,
+			tree:     tree,
+			messages: merged,
+			metadata: metadata,
+		})
+	}
+
+	if err := l.applyMigration(planned, result, config); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// migrateToNested 迁移到分层结构
+// 将 locales/en.json 按模块拆分为 locales/en/<module>.json
+func (l *LocaleLoader) migrateToNested(config MigrationConfig) (*MigrationResult, error) {
+	files, err := l.sourceFiles(FlatMode)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{}
+	var planned []plannedFile
+	for _, file := range files {
+		messages, metadata, err := parseLocaleFileWithMetadata(l.fsys, file.Path, file.Lang, l.config.KeySeparator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file.Path, err)
+		}
+		tree, err := isTreeFile(l.fsys, file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file.Path, err)
+		}
+
+		var modules []string
+		byModule := make(map[string]*plannedFile)
+		format := fileFormat(file.Path)
+		for i, m := range messages {
+			module := config.moduleFor(m.ID)
+			target, exists := byModule[module]
+			if !exists {
+				modules = append(modules, module)
+				target = &plannedFile{
+					path:   filepath.Join(l.config.Path, file.Lang, module+"."+format),
+					format: format,
+					tree:   tree,
+				}
+				byModule[module] = target
+			}
+			target.messages = append(target.messages, m)
+			target.metadata = append(target.metadata, metadataAt(metadata, i))
+		}
+
+		for _, module := range modules {
+			planned = append(planned, *byModule[module])
+		}
+		result.Removed = append(result.Removed, file.Path)
+	}
+
+	if err := l.applyMigration(planned, result, config); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// sourceFiles 按源结构模式发现待迁移的语言文件
+func (l *LocaleLoader) sourceFiles(mode LocaleMode) ([]LocaleFile, error) {
+	sourceConfig := l.config
+	sourceConfig.Mode = mode
+	files, err := NewLocaleLoader(sourceConfig, nil).DiscoverLocaleFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s locale files found in %s", mode, l.config.Path)
+	}
+	return files, nil
+}
+
+// applyMigration 备份原文件、写入新文件并删除原文件
+func (l *LocaleLoader) applyMigration(planned []plannedFile, result *MigrationResult, config MigrationConfig) error {
+	removed := make(map[string]bool, len(result.Removed))
+	for _, path := range result.Removed {
+		removed[path] = true
+	}
+
+	contents := make([][]byte, len(planned))
+	for i, file := range planned {
+		if fileExists(l.fsys, file.path) && !removed[file.path] {
+			return fmt.Errorf("target file already exists: %s", file.path)
+		}
+
+		data, err := encodeLocaleFile(file.format, file.tree, file.messages, file.metadata, l.config.KeySeparator)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", file.path, err)
+		}
+		contents[i] = data
+		result.Written = append(result.Written, file.path)
+	}
+
+	if config.DryRun {
+		return nil
+	}
+
+	if config.BackupDir != "" {
+		if err := l.backupFiles(result.Removed, config.BackupDir); err != nil {
+			return err
+		}
+		result.BackupDir = config.BackupDir
+	}
+
+	for i, file := range planned {
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file.path, err)
+		}
+		if err := os.WriteFile(file.path, contents[i], 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.path, err)
+		}
+	}
+
+	for _, path := range result.Removed {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		// 删除迁移后变为空的语言目录
+		if dir := filepath.Dir(path); dir != filepath.Clean(l.config.Path) {
+			if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+				os.Remove(dir)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backupFiles 将文件按相对路径复制到备份目录
+func (l *LocaleLoader) backupFiles(paths []string, backupDir string) error {
+	for _, path := range paths {
+		rel, err := filepath.Rel(l.config.Path, path)
+		if err != nil {
+			return fmt.Errorf("failed to backup %s: %w", path, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to backup %s: %w", path, err)
+		}
+
+		target := filepath.Join(backupDir, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to backup %s: %w", path, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("failed to backup %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// moduleFor 确定消息ID所属的模块
+// 优先级: 显式映射 > 显式前缀映射（最长优先） > ID 前缀与模块名匹配 > 默认模块
+func (c MigrationConfig) moduleFor(id string) string {
+	bestModule, bestLen := "", -1
+	for module, patterns := range c.ModuleMapping {
+		for _, pattern := range patterns {
+			if pattern == id {
+				return module
+			}
+			prefix, ok := strings.CutSuffix(pattern, "*")
+			if ok && strings.HasPrefix(id, prefix) && len(prefix) > bestLen {
+				bestModule, bestLen = module, len(prefix)
+			}
+		}
+	}
+	if bestModule != "" {
+		return bestModule
+	}
+
+	modules := c.Modules
+	if len(modules) == 0 {
+		modules = []string{"common", "errors", "ui"}
+	}
+
+	head := strings.ToLower(id)
+	if i := strings.IndexAny(head, "._"); i >= 0 {
+		head = head[:i]
+	}
+	for _, module := range modules {
+		name := strings.ToLower(module)
+		if head == name || head == strings.TrimSuffix(name, "s") {
+			return module
+		}
+	}
+
+	if c.DefaultModule != "" {
+		return c.DefaultModule
+	}
+	return "common"
+}
+
+// groupByLanguage 按语言分组，保持发现顺序
+func groupByLanguage(files []LocaleFile) [][]LocaleFile {
+	var groups [][]LocaleFile
+	index := make(map[string]int)
+	for _, file := range files {
+		i, exists := index[file.Lang]
+		if !exists {
+			i = len(groups)
+			index[file.Lang] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], file)
+	}
+	return groups
+}
+
+// sameTranslation 比较两条消息的翻译内容是否一致
+func sameTranslation(a, b *i18n.Message) bool {
+	return a.Zero == b.Zero && a.One == b.One && a.Two == b.Two &&
+		a.Few == b.Few && a.Many == b.Many && a.Other == b.Other &&
+		a.LeftDelim == b.LeftDelim && a.RightDelim == b.RightDelim
+}
+
+// messageEntry 写入语言文件的消息格式
+// 只有 other 形式时使用 translation 字段，与项目现有文件保持一致
+type messageEntry struct {
+	ID          string `json:"id" yaml:"id" toml:"-"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
+	Hash        string `json:"hash,omitempty" yaml:"hash,omitempty" toml:"hash,omitempty"`
+	LeftDelim   string `json:"leftDelim,omitempty" yaml:"leftDelim,omitempty" toml:"leftDelim,omitempty"`
+	RightDelim  string `json:"rightDelim,omitempty" yaml:"rightDelim,omitempty" toml:"rightDelim,omitempty"`
+	Translation string `json:"translation,omitempty" yaml:"translation,omitempty" toml:"-"`
+	Zero        string `json:"zero,omitempty" yaml:"zero,omitempty" toml:"zero,omitempty"`
+	One         string `json:"one,omitempty" yaml:"one,omitempty" toml:"one,omitempty"`
+	Two         string `json:"two,omitempty" yaml:"two,omitempty" toml:"two,omitempty"`
+	Few         string `json:"few,omitempty" yaml:"few,omitempty" toml:"few,omitempty"`
+	Many        string `json:"many,omitempty" yaml:"many,omitempty" toml:"many,omitempty"`
+	Other       string `json:"other,omitempty" yaml:"other,omitempty" toml:"other,omitempty"`
+
+	MaxLength    int      `json:"max_length,omitempty" yaml:"max_length,omitempty" toml:"max_length,omitempty"`
+	Placeholders []string `json:"placeholders,omitempty" yaml:"placeholders,omitempty" toml:"placeholders,omitempty"`
+	Owner        string   `json:"owner,omitempty" yaml:"owner,omitempty" toml:"owner,omitempty"`
+}
+
+// newMessageEntry 将消息及其元数据（可以为空）转换为写入格式
+func newMessageEntry(m *i18n.Message, meta *MessageMetadata) messageEntry {
+	entry := messageEntry{
+		ID:          m.ID,
+		Description: m.Description,
+		Hash:        m.Hash,
+		LeftDelim:   m.LeftDelim,
+		RightDelim:  m.RightDelim,
+	}
+	if meta != nil {
+		entry.MaxLength, entry.Placeholders, entry.Owner = meta.MaxLength, meta.Placeholders, meta.Owner
+	}
+	if !IsPluralMessage(m) {
+		entry.Translation = m.Other
+		return entry
+	}
+	entry.Zero, entry.One, entry.Two = m.Zero, m.One, m.Two
+	entry.Few, entry.Many, entry.Other = m.Few, m.Many, m.Other
+	return entry
+}
+
+// encodeMessages 将消息编码为指定格式，metadata 按下标与消息对应，可以为空
+// JSON 和 YAML 写为消息数组，TOML 不支持顶层数组，写为以消息ID为键的表
+func encodeMessages(format string, messages []*i18n.Message, metadata []*MessageMetadata) ([]byte, error) {
+	entries := make([]messageEntry, len(messages))
+	for i, m := range messages {
+		entries[i] = newMessageEntry(m, metadataAt(metadata, i))
+	}
+
+	if format != "toml" {
+		return encodeValue(format, entries)
+	}
+
+	tables := make(map[string]messageEntry, len(entries))
+	for _, entry := range entries {
+		if entry.Translation != "" {
+			entry.Other, entry.Translation = entry.Translation, ""
+		}
+		tables[entry.ID] = entry
+	}
+	return toml.Marshal(tables)
+}
+
+// encodeLocaleFile 按语言文件的组织形式编码消息及其元数据：tree 为 true 时按 separator 还原为树形结构，
+// 否则写为消息数组（见 encodeMessages）
+func encodeLocaleFile(format string, tree bool, messages []*i18n.Message, metadata []*MessageMetadata, separator string) ([]byte, error) {
+	if !tree {
+		return encodeMessages(format, messages, metadata)
+	}
+	messageTree, err := unflattenMessages(messages, metadata, separator)
+	if err != nil {
+		return nil, err
+	}
+	return encodeTree(format, messageTree)
+}
+
+// encodeTree 将树形结构的消息编码为指定格式
+func encodeTree(format string, tree map[string]interface{}) ([]byte, error) {
+	if format == "toml" {
+		return toml.Marshal(tree)
+	}
+	return encodeValue(format, tree)
+}
+
+// encodeValue 将值编码为 JSON 或 YAML
+func encodeValue(format string, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+	case "yaml", "yml":
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+	return buf.Bytes(), nil
+}
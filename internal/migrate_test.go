@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateToFlatReportsConflicts(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en/common.json": `[{"id": "WELCOME", "translation": "Welcome"}, {"id": "OK", "translation": "OK"}]`,
+		"en/ui.json":     `[{"id": "WELCOME", "translation": "Hi"}, {"id": "OK", "translation": "OK"}]`,
+		"fr/common.json": `[{"id": "WELCOME", "translation": "Bienvenue"}]`,
+	})
+	backupDir := filepath.Join(t.TempDir(), "backup")
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir, Languages: []string{"en"}}, nil)
+	result, err := loader.MigrateLocaleStructure(MigrationConfig{
+		FromMode:  NestedMode,
+		ToMode:    FlatMode,
+		BackupDir: backupDir,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, "WELCOME", result.Conflicts[0].ID)
+	assert.Equal(t, []string{filepath.Join(dir, "en.json")}, result.Written)
+
+	messages, err := parseLocaleFile(osFS{}, filepath.Join(dir, "en.json"), "en", "")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "Welcome", messages[0].Other)
+
+	assert.False(t, dirExists(osFS{}, filepath.Join(dir, "en")))
+	assert.True(t, fileExists(osFS{}, filepath.Join(backupDir, "en", "ui.json")))
+	// 未选中的语言保持不变
+	assert.True(t, fileExists(osFS{}, filepath.Join(dir, "fr", "common.json")))
+}
+
+func TestMigrateToNestedByPrefixAndMapping(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `[
+			{"id": "ERROR_TIMEOUT", "translation": "Timeout"},
+			{"id": "WELCOME_SUBJECT", "translation": "Welcome"},
+			{"id": "FILES", "one": "{{.Count}} file", "other": "{{.Count}} files"},
+			{"id": "GOODBYE", "translation": "Goodbye"}
+		]`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, nil)
+	config := MigrationConfig{
+		FromMode:      FlatMode,
+		ToMode:        NestedMode,
+		Modules:       []string{"common", "errors", "emails"},
+		ModuleMapping: map[string][]string{"emails": {"WELCOME_*"}, "ui": {"FILES"}},
+	}
+
+	dryRun := config
+	dryRun.DryRun = true
+	result, err := loader.MigrateLocaleStructure(dryRun)
+	require.NoError(t, err)
+	assert.Len(t, result.Written, 4)
+	assert.True(t, fileExists(osFS{}, filepath.Join(dir, "en.json")), "dry run must not touch files")
+
+	_, err = loader.MigrateLocaleStructure(config)
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"errors": "ERROR_TIMEOUT",
+		"emails": "WELCOME_SUBJECT",
+		"ui":     "FILES",
+		"common": "GOODBYE",
+	}
+	for module, id := range expected {
+		messages, err := parseLocaleFile(osFS{}, filepath.Join(dir, "en", module+".json"), "en", "")
+		require.NoError(t, err, module)
+		require.Len(t, messages, 1, module)
+		assert.Equal(t, id, messages[0].ID)
+	}
+
+	plural, err := parseLocaleFile(osFS{}, filepath.Join(dir, "en", "ui.json"), "en", "")
+	require.NoError(t, err)
+	assert.Equal(t, "{{.Count}} file", plural[0].One)
+
+	_, err = os.Stat(filepath.Join(dir, "en.json"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMigrateKeepsSourceFormat(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en/common.yaml": "- id: WELCOME\n  translation: Welcome\n",
+		"en/ui.yaml":     "- id: FILES\n  one: one file\n  other: many files\n",
+		"fr/common.toml": "WELCOME = \"Bienvenue\"\n",
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, nil)
+	_, err := loader.MigrateLocaleStructure(MigrationConfig{FromMode: NestedMode, ToMode: FlatMode})
+	require.NoError(t, err)
+
+	messages, err := parseLocaleFile(osFS{}, filepath.Join(dir, "en.yaml"), "en", "")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "many files", messages[1].Other)
+	assert.Equal(t, "one file", messages[1].One)
+
+	messages, err = parseLocaleFile(osFS{}, filepath.Join(dir, "fr.toml"), "fr", "")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Bienvenue", messages[0].Other)
+}
+
+func TestMigrateToNestedKeepsTreeShapeAndMetadata(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{
+			"errors": {"timeout": {"other": "Timeout", "description": "Request timeout", "max_length": 20, "owner": "platform"}},
+			"ui": {"files": {"one": "{{.Count}} file", "other": "{{.Count}} files", "placeholders": ["Count"]}},
+			"welcome": "Welcome"
+		}`,
+		"de.json": `[{"id": "ui.files", "one": "{{.Count}} Datei", "other": "{{.Count}} Dateien", "owner": "de-team"}]`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, nil)
+	_, err := loader.MigrateLocaleStructure(MigrationConfig{FromMode: FlatMode, ToMode: NestedMode})
+	require.NoError(t, err)
+
+	// 树形文件拆分后仍为树形，元数据保留
+	for file, want := range map[string]string{
+		"en/errors.json": `{"errors": {"timeout": {"other": "Timeout", "description": "Request timeout", "max_length": 20, "owner": "platform"}}}`,
+		"en/ui.json":     `{"ui": {"files": {"one": "{{.Count}} file", "other": "{{.Count}} files", "placeholders": ["Count"]}}}`,
+		"en/common.json": `{"welcome": "Welcome"}`,
+		"de/ui.json":     `[{"id": "ui.files", "one": "{{.Count}} Datei", "other": "{{.Count}} Dateien", "owner": "de-team"}]`,
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err, file)
+		assert.JSONEq(t, want, string(data), file)
+	}
+
+	// 合并回扁平结构同样保留
+	_, err = loader.MigrateLocaleStructure(MigrationConfig{FromMode: NestedMode, ToMode: FlatMode})
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "en.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"errors": {"timeout": {"other": "Timeout", "description": "Request timeout", "max_length": 20, "owner": "platform"}},
+		"ui": {"files": {"one": "{{.Count}} file", "other": "{{.Count}} files", "placeholders": ["Count"]}},
+		"welcome": "Welcome"
+	}`, string(data))
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultMissingLimit 缺失翻译记录的默认数量上限
+const DefaultMissingLimit = 10000
+
+// maxMissingCallers 每条记录保留的调用位置数量
+const maxMissingCallers = 5
+
+// MissingRecord 缺失翻译的记录，同一语言的同一消息只记录一次
+type MissingRecord struct {
+	Lang      string    `json:"lang"`
+	MessageID string    `json:"message_id"`
+	Count     int64     `json:"count"`
+	Callers   []string  `json:"callers"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+type missingKey struct {
+	lang, messageID string
+}
+
+// MissingRegistry 按语言和消息ID去重的缺失翻译记录，并发安全
+// 记录数量达到上限后不再记录新的消息，已有记录的计数仍会更新
+type MissingRegistry struct {
+	mu      sync.Mutex
+	records map[missingKey]*MissingRecord
+	limit   int
+	dropped int64
+}
+
+// NewMissingRegistry 创建缺失翻译记录，limit 不大于 0 时使用 DefaultMissingLimit
+func NewMissingRegistry(limit int) *MissingRegistry {
+	if limit <= 0 {
+		limit = DefaultMissingLimit
+	}
+	return &MissingRegistry{
+		records: make(map[missingKey]*MissingRecord),
+		limit:   limit,
+	}
+}
+
+// Record 记录一次缺失的翻译，caller 为调用位置（可以为空）
+func (r *MissingRegistry) Record(lang, messageID, caller string) {
+	now := time.Now()
+	key := missingKey{lang, messageID}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.records[key]
+	if !exists {
+		if len(r.records) >= r.limit {
+			r.dropped++
+			return
+		}
+		record = &MissingRecord{Lang: lang, MessageID: messageID, FirstSeen: now}
+		r.records[key] = record
+	}
+
+	record.Count++
+	record.LastSeen = now
+	if caller != "" && len(record.Callers) < maxMissingCallers && !containsString(record.Callers, caller) {
+		record.Callers = append(record.Callers, caller)
+	}
+}
+
+// Records 返回所有记录的副本，按语言和消息ID排序
+func (r *MissingRegistry) Records() []MissingRecord {
+	r.mu.Lock()
+	records := make([]MissingRecord, 0, len(r.records))
+	for _, record := range r.records {
+		copied := *record
+		copied.Callers = append([]string(nil), record.Callers...)
+		records = append(records, copied)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Lang != records[j].Lang {
+			return records[i].Lang < records[j].Lang
+		}
+		return records[i].MessageID < records[j].MessageID
+	})
+	return records
+}
+
+// Dropped 返回因达到数量上限而没有记录的次数
+func (r *MissingRegistry) Dropped() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped
+}
+
+// Reset 清空所有记录
+func (r *MissingRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = make(map[missingKey]*MissingRecord)
+	r.dropped = 0
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingRegistry(t *testing.T) {
+	r := NewMissingRegistry(2)
+	r.Record("fr", "HELLO", "a.go:1")
+	r.Record("fr", "HELLO", "a.go:1")
+	r.Record("fr", "HELLO", "b.go:2")
+	r.Record("de", "HELLO", "")
+	r.Record("ja", "HELLO", "a.go:1") // 超过上限
+
+	records := r.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "de", records[0].Lang)
+	assert.Empty(t, records[0].Callers)
+	assert.Equal(t, int64(3), records[1].Count)
+	assert.Equal(t, []string{"a.go:1", "b.go:2"}, records[1].Callers)
+	assert.False(t, records[1].FirstSeen.After(records[1].LastSeen))
+	assert.Equal(t, int64(1), r.Dropped())
+
+	r.Reset()
+	assert.Empty(t, r.Records())
+	assert.Zero(t, r.Dropped())
+}
@@ -0,0 +1,312 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// Override 运行时覆盖的消息，优先于语言文件中的同一消息
+type Override struct {
+	Lang      string    `json:"lang"`
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OverrideStore 运行时消息覆盖，每种语言使用独立的 bundle，不影响语言文件加载的消息
+// 设置了文件路径时每次修改都会写入该 JSON 文件，创建时从文件恢复
+type OverrideStore struct {
+	mu         sync.RWMutex
+	path       string
+	overrides  map[string]map[string]Override // 语言 -> 消息ID -> 覆盖
+	localizers map[string]*i18n.Localizer     // 语言 -> 只包含覆盖消息的 Localizer
+}
+
+// NewOverrideStore 创建消息覆盖存储，path 为空时不持久化，文件不存在时视为没有覆盖
+func NewOverrideStore(path string) (*OverrideStore, error) {
+	s := &OverrideStore{
+		path:       path,
+		overrides:  make(map[string]map[string]Override),
+		localizers: make(map[string]*i18n.Localizer),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overrides file: %w", err)
+	}
+
+	var overrides []Override
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse overrides file %s: %w", path, err)
+	}
+	for _, o := range overrides {
+		lang, err := overrideLanguage(o.Lang)
+		if err != nil {
+			return nil, fmt.Errorf("overrides file %s: %w", path, err)
+		}
+		o.Lang = lang
+		if s.overrides[lang] == nil {
+			s.overrides[lang] = make(map[string]Override)
+		}
+		s.overrides[lang][o.ID] = o
+	}
+	for lang := range s.overrides {
+		if err := s.rebuild(lang); err != nil {
+			return nil, fmt.Errorf("overrides file %s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+// overrideLanguage 解析并规范化语言代码
+func overrideLanguage(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", fmt.Errorf("invalid language %q: %w", lang, err)
+	}
+	return tag.String(), nil
+}
+
+// Set 设置消息覆盖，持久化失败时不修改内存中的覆盖
+func (s *OverrideStore) Set(lang, id, text string) error {
+	lang, err := overrideLanguage(lang)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return fmt.Errorf("message id cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(lang, func(messages map[string]Override) {
+		messages[id] = Override{Lang: lang, ID: id, Text: text, UpdatedAt: time.Now().UTC().Round(0)}
+	})
+}
+
+// Remove 删除消息覆盖，返回覆盖是否存在
+func (s *OverrideStore) Remove(lang, id string) (bool, error) {
+	lang, err := overrideLanguage(lang)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.overrides[lang][id]; !ok {
+		return false, nil
+	}
+	return true, s.update(lang, func(messages map[string]Override) {
+		delete(messages, id)
+	})
+}
+
+// update 复制语言的覆盖并修改，写入文件成功后替换，调用方需持有写锁
+func (s *OverrideStore) update(lang string, modify func(map[string]Override)) error {
+	previous := s.overrides[lang]
+	messages := make(map[string]Override, len(previous)+1)
+	for id, o := range previous {
+		messages[id] = o
+	}
+	modify(messages)
+
+	s.overrides[lang] = messages
+	if len(messages) == 0 {
+		delete(s.overrides, lang)
+	}
+	err := s.save()
+	if err == nil {
+		err = s.rebuild(lang)
+	}
+	if err != nil {
+		if previous == nil {
+			delete(s.overrides, lang)
+		} else {
+			s.overrides[lang] = previous
+		}
+		return err
+	}
+	return nil
+}
+
+// rebuild 重新创建语言的覆盖 bundle，调用方需持有写锁
+// 覆盖文本用于所有复数形式，因此复数翻译也会使用覆盖
+func (s *OverrideStore) rebuild(lang string) error {
+	messages := s.overrides[lang]
+	if len(messages) == 0 {
+		delete(s.localizers, lang)
+		return nil
+	}
+
+	tag := language.Make(lang)
+	bundle := i18n.NewBundle(tag)
+	for id, o := range messages {
+		m := &i18n.Message{ID: id, Zero: o.Text, One: o.Text, Two: o.Text, Few: o.Text, Many: o.Text, Other: o.Text}
+		if err := bundle.AddMessages(tag, m); err != nil {
+			return fmt.Errorf("failed to add override %s (%s): %w", id, lang, err)
+		}
+	}
+	s.localizers[lang] = i18n.NewLocalizer(bundle, lang)
+	return nil
+}
+
+// save 将所有覆盖写入文件（先写临时文件再重命名），调用方需持有写锁
+func (s *OverrideStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.list(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode overrides: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create overrides directory: %w", err)
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write overrides file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write overrides file: %w", err)
+	}
+	return nil
+}
+
+// Get 返回消息覆盖
+func (s *OverrideStore) Get(lang, id string) (Override, bool) {
+	if s == nil {
+		return Override{}, false
+	}
+	lang, err := overrideLanguage(lang)
+	if err != nil {
+		return Override{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.overrides[lang][id]
+	return o, ok
+}
+
+// List 返回所有覆盖，按消息ID、语言排序
+func (s *OverrideStore) List() []Override {
+	if s == nil {
+		return []Override{}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list()
+}
+
+func (s *OverrideStore) list() []Override {
+	overrides := []Override{}
+	for _, messages := range s.overrides {
+		for _, o := range messages {
+			overrides = append(overrides, o)
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].ID != overrides[j].ID {
+			return overrides[i].ID < overrides[j].ID
+		}
+		return overrides[i].Lang < overrides[j].Lang
+	})
+	return overrides
+}
+
+// Languages 返回覆盖了消息的语言，按语言代码排序
+func (s *OverrideStore) Languages(id string) []string {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var languages []string
+	for lang, messages := range s.overrides {
+		if _, ok := messages[id]; ok {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Localizer 返回只包含该语言覆盖消息的 Localizer，没有覆盖时返回 nil
+func (s *OverrideStore) Localizer(lang string) *i18n.Localizer {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.localizers) == 0 {
+		return nil
+	}
+	if loc, ok := s.localizers[lang]; ok {
+		return loc
+	}
+	if lang, err := overrideLanguage(lang); err == nil {
+		return s.localizers[lang]
+	}
+	return nil
+}
+
+// CacheKeyMatcher 返回匹配消息翻译结果缓存键（格式见 BuildCacheKey）的函数，按缓存键中的消息ID比较：
+// 消息ID中的变体会被忽略，即匹配该消息所有语言、所有变体的缓存；
+// 不带命名空间的消息ID同时匹配各模块中的同名消息（errors:NOT_FOUND），带命名空间时只匹配该模块
+func CacheKeyMatcher(id string) func(key string) bool {
+	base, _, _ := strings.Cut(id, ContextSeparator)
+	anyModule := !strings.Contains(base, NamespaceSeparator)
+	return func(key string) bool {
+		keyID, ok := cacheKeyMessageID(key)
+		if !ok {
+			return false
+		}
+		keyID, _, _ = strings.Cut(keyID, ContextSeparator)
+		if keyID == base {
+			return true
+		}
+		_, name, namespaced := strings.Cut(keyID, NamespaceSeparator)
+		return anyModule && namespaced && name == base
+	}
+}
+
+// cacheKeyMessageID 取出 BuildCacheKey 构建的缓存键中的消息ID（含变体）
+func cacheKeyMessageID(key string) (string, bool) {
+	_, rest, ok := strings.Cut(key, ":")
+	if !ok {
+		return "", false
+	}
+	for _, segment := range []string{cacheKeyCount, cacheKeyData} {
+		if i := strings.Index(rest, segment); i >= 0 {
+			rest = rest[:i]
+		}
+	}
+	return rest, true
+}
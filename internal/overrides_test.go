@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverrideStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "overrides.json")
+
+	store, err := NewOverrideStore(path)
+	require.NoError(t, err)
+	assert.Nil(t, store.Localizer("en"))
+	assert.Empty(t, store.List())
+
+	require.NoError(t, store.Set("zh-cn", "WELCOME", "欢迎，{{.Name}}"))
+	require.NoError(t, store.Set("en", "WELCOME", "Hi {{.Name}}"))
+	require.NoError(t, store.Set("en", "BYE", "Bye"))
+	assert.Error(t, store.Set("not a language", "WELCOME", "x"))
+	assert.Error(t, store.Set("en", "", "x"))
+
+	o, ok := store.Get("zh-CN", "WELCOME")
+	require.True(t, ok)
+	assert.Equal(t, "zh-CN", o.Lang)
+	assert.Equal(t, []string{"en", "zh-CN"}, store.Languages("WELCOME"))
+
+	loc := store.Localizer("zh-CN")
+	require.NotNil(t, loc)
+	translated, err := loc.Localize(&i18n.LocalizeConfig{MessageID: "WELCOME", TemplateData: map[string]string{"Name": "小明"}, PluralCount: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "欢迎，小明", translated)
+	_, err = loc.Localize(&i18n.LocalizeConfig{MessageID: "BYE"})
+	assert.Error(t, err)
+
+	removed, err := store.Remove("en", "BYE")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = store.Remove("en", "BYE")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	// 从文件恢复
+	restored, err := NewOverrideStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, store.List(), restored.List())
+	assert.NotNil(t, restored.Localizer("en"))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"en": "x"}`), 0644))
+	_, err = NewOverrideStore(path)
+	assert.Error(t, err)
+}
+
+func TestCacheKeyMatcher(t *testing.T) {
+	data := []map[string]interface{}{{"Name": "Ann"}}
+	match := CacheKeyMatcher("errors:NOT_FOUND#female")
+	assert.True(t, match(BuildCacheKey("en", "errors:NOT_FOUND", nil, nil)))
+	assert.True(t, match(BuildCacheKey("zh-CN@Asia/Shanghai", "errors:NOT_FOUND#formal", 1, data)))
+	assert.True(t, match(BuildCacheKey("en", "errors:NOT_FOUND", 2.5, nil)))
+	assert.True(t, match(BuildCacheKey("en", "errors:NOT_FOUND", nil, data)))
+	assert.False(t, match(BuildCacheKey("en", "errors:NOT_FOUND_USER", 1, data)))
+	assert.False(t, match(BuildCacheKey("en", "WELCOME", nil, nil)))
+
+	// 命名空间不会被当作消息ID后面的段
+	match = CacheKeyMatcher("errors")
+	assert.True(t, match(BuildCacheKey("en", "errors", 1, data)))
+	assert.False(t, match(BuildCacheKey("en", "errors:NOT_FOUND", nil, data)))
+
+	// 不带命名空间的消息ID匹配各模块中的同名消息，带命名空间时只匹配该模块
+	match = CacheKeyMatcher("NOT_FOUND")
+	assert.True(t, match(BuildCacheKey("en", "NOT_FOUND", nil, nil)))
+	assert.True(t, match(BuildCacheKey("en", "errors:NOT_FOUND#formal", 1, data)))
+	assert.True(t, match(BuildCacheKey("de@Europe/Berlin", "users:NOT_FOUND", nil, data)))
+	assert.False(t, match(BuildCacheKey("en", "errors:NOT_FOUND_USER", nil, nil)))
+	match = CacheKeyMatcher("errors:NOT_FOUND")
+	assert.False(t, match(BuildCacheKey("en", "NOT_FOUND", nil, nil)))
+	assert.False(t, match(BuildCacheKey("en", "users:NOT_FOUND", nil, nil)))
+
+	// 以消息ID开头的其他消息不匹配
+	match = CacheKeyMatcher("ID")
+	for _, sibling := range []string{"ID2", "ID_OTHER", "ID2#female", "errors:ID2"} {
+		assert.False(t, match(BuildCacheKey("en", sibling, nil, nil)), sibling)
+		assert.False(t, match(BuildCacheKey("en", sibling, 1, nil)), sibling)
+		assert.False(t, match(BuildCacheKey("en", sibling, nil, data)), sibling)
+	}
+	assert.True(t, match(BuildCacheKey("en", "ID", 2, data)))
+}
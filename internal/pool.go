@@ -15,25 +15,47 @@ type LocalizerPool struct {
 	maxPoolSize int
 	stats      PoolStats
 	bundle     *i18n.Bundle
-	fallbackLang string
+	fallbacks  *FallbackChains
 }
 
 // NewLocalizerPool 创建 Localizer 池
 func NewLocalizerPool(maxPoolSize int, bundle *i18n.Bundle, fallbackLang string) PoolManager {
+	return NewLocalizerPoolWithChains(maxPoolSize, bundle, NewFallbackChains(nil, fallbackLang))
+}
+
+// NewLocalizerPoolWithChains 创建 Localizer 池，Localizer 按语言的降级链匹配
+func NewLocalizerPoolWithChains(maxPoolSize int, bundle *i18n.Bundle, fallbacks *FallbackChains) PoolManager {
 	return &LocalizerPool{
-		pools:        make(map[string]*sync.Pool),
-		poolMap:      make(map[string]int),
-		maxPoolSize:  maxPoolSize,
-		stats:        PoolStats{},
-		bundle:       bundle,
-		fallbackLang: fallbackLang,
+		pools:       make(map[string]*sync.Pool),
+		poolMap:     make(map[string]int),
+		maxPoolSize: maxPoolSize,
+		stats:       PoolStats{},
+		bundle:      bundle,
+		fallbacks:   fallbacks,
+	}
+}
+
+// newLocalizer 创建按降级链匹配的 Localizer
+func (p *LocalizerPool) newLocalizer(bundle *i18n.Bundle, lang string) *i18n.Localizer {
+	return i18n.NewLocalizer(bundle, p.fallbacks.Chain(lang)...)
+}
+
+// newPool 创建语言的 sync.Pool，调用方需持有写锁
+func (p *LocalizerPool) newPool(lang string) {
+	bundle := p.bundle
+	p.pools[lang] = &sync.Pool{
+		New: func() interface{} {
+			return p.newLocalizer(bundle, lang)
+		},
 	}
+	p.poolMap[lang] = 0
 }
 
 // Get 获取 Localizer
 func (p *LocalizerPool) Get(lang string) *i18n.Localizer {
 	p.mu.RLock()
 	pool, exists := p.pools[lang]
+	bundle := p.bundle
 	p.mu.RUnlock()
 
 	if exists {
@@ -44,7 +66,7 @@ func (p *LocalizerPool) Get(lang string) *i18n.Localizer {
 	}
 
 	// 池中没有或为空，创建新的
-	newLocalizer := i18n.NewLocalizer(p.bundle, lang, p.fallbackLang)
+	newLocalizer := p.newLocalizer(bundle, lang)
 	p.stats.recordCreate()
 	return newLocalizer
 }
@@ -60,12 +82,7 @@ func (p *LocalizerPool) Put(lang string, localizer *i18n.Localizer) {
 
 	// 如果池还没创建，先创建
 	if _, exists := p.pools[lang]; !exists {
-		p.pools[lang] = &sync.Pool{
-			New: func() interface{} {
-				return i18n.NewLocalizer(p.bundle, lang, p.fallbackLang)
-			},
-		}
-		p.poolMap[lang] = 0
+		p.newPool(lang)
 	}
 
 	// 检查池大小限制
@@ -86,18 +103,14 @@ func (p *LocalizerPool) WarmUp(languages []string) {
 	for _, lang := range languages {
 		p.mu.Lock()
 		if _, exists := p.pools[lang]; !exists {
-			p.pools[lang] = &sync.Pool{
-				New: func() interface{} {
-					return i18n.NewLocalizer(p.bundle, lang, p.fallbackLang)
-				},
-			}
-			p.poolMap[lang] = 0
+			p.newPool(lang)
 		}
+		bundle := p.bundle
 		p.mu.Unlock()
 
 		// 预创建一些 Localizer
 		for i := 0; i < 5; i++ {
-			localizer := i18n.NewLocalizer(p.bundle, lang, p.fallbackLang)
+			localizer := p.newLocalizer(bundle, lang)
 			p.Put(lang, localizer)
 		}
 	}
@@ -126,6 +139,16 @@ func (p *LocalizerPool) GetStats() PoolStats {
 	}
 }
 
+// Reset 清空池中的 Localizer，之后使用新的 bundle 创建
+func (p *LocalizerPool) Reset(bundle *i18n.Bundle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bundle = bundle
+	p.pools = make(map[string]*sync.Pool)
+	p.poolMap = make(map[string]int)
+}
+
 // Close 关闭池
 func (p *LocalizerPool) Close() error {
 	p.mu.Lock()
@@ -174,6 +197,9 @@ func (p *NoOpPool) Put(lang string, localizer *i18n.Localizer) {
 func (p *NoOpPool) WarmUp(languages []string) {
 }
 
+func (p *NoOpPool) Reset(bundle *i18n.Bundle) {
+}
+
 func (p *NoOpPool) GetStats() PoolStats {
 	return PoolStats{}
 }
@@ -191,7 +217,7 @@ func NewPoolManager(config PoolConfig, bundle *i18n.Bundle) PoolManager {
 		return &NoOpPool{}
 	}
 
-	return NewLocalizerPool(config.Size, bundle, config.FallbackLanguage)
+	return NewLocalizerPoolWithChains(config.Size, bundle, NewFallbackChains(config.FallbackChains, config.FallbackLanguage))
 }
 
 // PoolConfig 池配置（重新定义以避免循环依赖）
@@ -201,4 +227,5 @@ type PoolConfig struct {
 	WarmUp    bool     `yaml:"warm_up" json:"warm_up"`
 	Languages []string `yaml:"languages" json:"languages"`
 	FallbackLanguage string `yaml:"fallback_language" json:"fallback_language"`
+	FallbackChains map[string][]string `yaml:"fallback_chains" json:"fallback_chains"`
 }
\ No newline at end of file
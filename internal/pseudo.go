@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"strings"
+	texttemplate "text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"golang.org/x/text/language"
+)
+
+// PseudoLocale 伪本地化语言，由默认语言的消息实时生成，用于在没有真实翻译时检查界面
+type PseudoLocale string
+
+const (
+	// PseudoAccented 字母替换为带重音的字母并加长约 40%，如 "Welcome" -> "[Ŵéļçöɱé one two]"，
+	// 用于发现硬编码的字符串、截断和字符编码问题
+	PseudoAccented PseudoLocale = "en-XA"
+	// PseudoBidi 每个单词用 RTL 控制字符包裹，按从右到左显示，用于检查双向文本布局
+	PseudoBidi PseudoLocale = "ar-XB"
+)
+
+// ParsePseudoLocale 检查语言是否为伪本地化语言（不区分大小写）
+func ParsePseudoLocale(lang string) (PseudoLocale, bool) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", false
+	}
+	switch locale := PseudoLocale(tag.String()); locale {
+	case PseudoAccented, PseudoBidi:
+		return locale, true
+	}
+	return "", false
+}
+
+const (
+	accentedLower = "åƀçđéƒĝĥîĵķļɱñöþǫŕšţûṽŵẋýž"
+	accentedUpper = "ÅƁÇĐÉƑĜĤÎĴĶĻṀÑÖÞǪŔŠŢÛṼŴẊÝŽ"
+
+	// 加长文本时依次追加的单词
+	pseudoPadding = "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen"
+
+	rlm = "\u200f" // RIGHT-TO-LEFT MARK
+	rlo = "\u202e" // RIGHT-TO-LEFT OVERRIDE
+	pdf = "\u202c" // POP DIRECTIONAL FORMATTING
+)
+
+var accentedLetters = func() map[rune]rune {
+	m := make(map[rune]rune, 52)
+	lower, upper := []rune(accentedLower), []rune(accentedUpper)
+	for i := 0; i < 26; i++ {
+		m['a'+rune(i)] = lower[i]
+		m['A'+rune(i)] = upper[i]
+	}
+	return m
+}()
+
+// Transform 伪本地化消息，模板动作（leftDelim ... rightDelim，为空时为 {{ }}）、HTML 标签和实体保持不变
+func (p PseudoLocale) Transform(msg, leftDelim, rightDelim string) string {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+
+	var b strings.Builder
+	letters := 0
+	for msg != "" {
+		n := protectedPrefix(msg, leftDelim, rightDelim)
+		if n > 0 {
+			b.WriteString(msg[:n])
+			msg = msg[n:]
+			continue
+		}
+
+		end := 1
+		for end < len(msg) && protectedPrefix(msg[end:], leftDelim, rightDelim) == 0 {
+			end++
+		}
+		letters += p.transformText(&b, msg[:end])
+		msg = msg[end:]
+	}
+	prefix, suffix := p.affixes(letters)
+	return prefix + b.String() + suffix
+}
+
+// transformText 伪本地化纯文本片段（不加括号和填充），返回其中的字母数
+func (p PseudoLocale) transformText(b *strings.Builder, text string) int {
+	letters := 0
+	switch p {
+	case PseudoAccented:
+		for _, r := range text {
+			if accented, ok := accentedLetters[r]; ok {
+				letters++
+				r = accented
+			}
+			b.WriteRune(r)
+		}
+
+	case PseudoBidi:
+		inWord := false
+		for _, r := range text {
+			if unicode.IsSpace(r) {
+				if inWord {
+					b.WriteString(pdf + rlm)
+					inWord = false
+				}
+			} else if !inWord {
+				b.WriteString(rlm + rlo)
+				inWord = true
+			}
+			if unicode.IsLetter(r) {
+				letters++
+			}
+			b.WriteRune(r)
+		}
+		if inWord {
+			b.WriteString(pdf + rlm)
+		}
+
+	default:
+		b.WriteString(text)
+	}
+	return letters
+}
+
+// affixes 伪本地化后的整条消息前后添加的括号和填充（仅 en-XA），letters 为原文中的字母数
+func (p PseudoLocale) affixes(letters int) (prefix, suffix string) {
+	if p != PseudoAccented {
+		return "", ""
+	}
+
+	want := (letters*2 + 4) / 5 // 约 40%
+	padding := ""
+	for _, word := range strings.Fields(pseudoPadding) {
+		if utf8.RuneCountInString(padding) >= want {
+			break
+		}
+		padding += " " + word
+	}
+	return "[", padding + "]"
+}
+
+// protectedPrefix 返回 s 开头需要原样保留的片段长度：模板动作、HTML 标签或 HTML 实体
+func protectedPrefix(s, leftDelim, rightDelim string) int {
+	switch {
+	case strings.HasPrefix(s, leftDelim):
+		if end := strings.Index(s[len(leftDelim):], rightDelim); end >= 0 {
+			return len(leftDelim) + end + len(rightDelim)
+		}
+		return len(s)
+
+	case len(s) > 1 && s[0] == '<' && (s[1] == '/' || isASCIILetter(s[1])):
+		if end := strings.IndexByte(s, '>'); end >= 0 {
+			return end + 1
+		}
+
+	case s[0] == '&':
+		for i := 1; i < len(s) && i < 10; i++ {
+			if s[i] == ';' && i > 1 {
+				return i + 1
+			}
+			if !isASCIILetter(s[i]) && !(s[i] >= '0' && s[i] <= '9') && s[i] != '#' {
+				break
+			}
+		}
+	}
+	return 0
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// PseudoParser go-i18n 模板解析器，先伪本地化消息中的文本，再按 Go 模板解析
+type PseudoParser struct {
+	Locale PseudoLocale
+	Funcs  texttemplate.FuncMap
+}
+
+// Cacheable 伪本地化的模板不缓存（与使用模板函数的 TextParser 一致）
+func (p *PseudoParser) Cacheable() bool {
+	return false
+}
+
+// Parse 实现 template.Parser 接口
+func (p *PseudoParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	parser := &template.TextParser{Funcs: p.Funcs}
+	return parser.Parse(p.Locale.Transform(src, leftDelim, rightDelim), leftDelim, rightDelim)
+}
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParsePseudoLocale(t *testing.T) {
+	for lang, want := range map[string]PseudoLocale{"en-XA": PseudoAccented, "en-xa": PseudoAccented, "ar-XB": PseudoBidi} {
+		locale, ok := ParsePseudoLocale(lang)
+		assert.True(t, ok, lang)
+		assert.Equal(t, want, locale)
+	}
+	for _, lang := range []string{"en", "ar", "en-US", "invalid_lang"} {
+		_, ok := ParsePseudoLocale(lang)
+		assert.False(t, ok, lang)
+	}
+}
+
+func TestPseudoTransform(t *testing.T) {
+	assert.Equal(t, "[Ŵéļçöɱé one]", PseudoAccented.Transform("Welcome", "", ""))
+	assert.Equal(t, "[Ĥéļļö, {{.Name}}! <b>Ñéŵ</b> &amp; ĥöţ one two]", PseudoAccented.Transform("Hello, {{.Name}}! <b>New</b> &amp; hot", "", ""))
+	assert.Equal(t, "[Ĥî <%.Name%> one]", PseudoAccented.Transform("Hi <%.Name%>", "<%", "%>"))
+
+	// 模板动作不包裹，由执行结果决定方向
+	assert.Equal(t, "\u200f\u202eHello,\u202c\u200f {{.Name}}", PseudoBidi.Transform("Hello, {{.Name}}", "", ""))
+}
+
+func TestPseudolocalizeICU(t *testing.T) {
+	msg, err := ParseICU("{count, plural, one {# file} other {# files}} for {name}")
+	require.NoError(t, err)
+
+	got, err := msg.Pseudolocalize(PseudoAccented).Format(language.English, map[string]interface{}{"count": 2, "name": "Ann"})
+	require.NoError(t, err)
+	assert.Equal(t, "[2 ƒîļéš ƒöŕ Ann one]", got)
+}
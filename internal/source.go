@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// MessageSource 消息来源，如语言文件目录或数据库
+type MessageSource interface {
+	// Languages 返回消息来源中的语言
+	Languages() ([]string, error)
+	// LoadMessages 加载一种语言的所有消息
+	LoadMessages(lang string) ([]*i18n.Message, error)
+	// Watch 监听消息变化，变化时调用 onChange；返回的 io.Closer 用于停止监听，不支持监听时返回 nil
+	Watch(onChange func()) (io.Closer, error)
+}
+
+// SourceName 消息来源的名称，用于错误和日志，实现了 fmt.Stringer 时使用 String()
+func SourceName(source MessageSource) string {
+	if s, ok := source.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", source)
+}
+
+// loadSource 加载消息来源中的所有语言，并将消息记录到 catalog
+// 某个语言加载失败不会中断其他语言的加载，错误记录到 loadErr
+func (l *LocaleLoader) loadSource(source MessageSource, catalog *messageCatalog, loadErr *LoadError) {
+	name := SourceName(source)
+	languages, err := source.Languages()
+	if err != nil {
+		loadErr.Files = append(loadErr.Files, FileError{Path: name, Err: err})
+		return
+	}
+
+	// 与语言文件相同，先加载通用语言再加载地区语言
+	languages = append([]string(nil), languages...)
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languageDepth(languages[i]) < languageDepth(languages[j])
+	})
+
+	for _, lang := range languages {
+		if !l.acceptLanguage(lang) {
+			continue
+		}
+
+		file := LocaleFile{Path: name, Lang: lang}
+		err := l.loadSourceLanguage(source, file, catalog)
+		if err != nil {
+			loadErr.Files = append(loadErr.Files, FileError{Path: name, Lang: lang, Err: err})
+			if l.config.Debug {
+				log.Printf("[i18n] Failed to load %s (%s): %v", name, lang, err)
+			}
+			continue
+		}
+		if l.config.Debug {
+			log.Printf("[i18n] Loaded messages from %s (%s)", name, lang)
+		}
+	}
+}
+
+// loadSourceLanguage 加载消息来源中的一种语言
+func (l *LocaleLoader) loadSourceLanguage(source MessageSource, file LocaleFile, catalog *messageCatalog) error {
+	messages, err := source.LoadMessages(file.Lang)
+	if err != nil {
+		return err
+	}
+	return l.addMessages(file, messages, nil, true, catalog)
+}
+
+// FileSource 语言文件目录消息来源，与服务自身加载的语言文件使用相同的发现和解析规则，
+// 用于在主目录之外组合其他目录（如插件或客户定制的语言文件）
+type FileSource struct {
+	loader *LocaleLoader
+}
+
+// NewFileSource 创建语言文件目录消息来源，config.Sources 会被忽略
+func NewFileSource(config LocaleLoaderConfig) *FileSource {
+	config.Sources = nil
+	return &FileSource{loader: NewLocaleLoader(config, nil)}
+}
+
+// String 实现 fmt.Stringer
+func (s *FileSource) String() string {
+	return s.loader.config.Path
+}
+
+// Languages 返回目录中的语言，按发现顺序
+func (s *FileSource) Languages() ([]string, error) {
+	files, err := s.loader.DiscoverLocaleFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var languages []string
+	for _, file := range files {
+		if !containsString(languages, file.Lang) {
+			languages = append(languages, file.Lang)
+		}
+	}
+	return languages, nil
+}
+
+// LoadMessages 解析一种语言的所有语言文件，启用命名空间时分层模式下的消息ID带有模块前缀
+func (s *FileSource) LoadMessages(lang string) ([]*i18n.Message, error) {
+	files, err := s.loader.DiscoverLocaleFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*i18n.Message
+	for _, file := range files {
+		if !strings.EqualFold(file.Lang, lang) {
+			continue
+		}
+		parsed, err := s.loader.parseLocaleFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Path, err)
+		}
+		if s.loader.config.Namespaces && file.Module != "" {
+			for _, m := range parsed {
+				m.ID = file.Module + NamespaceSeparator + m.ID
+			}
+		}
+		messages = append(messages, parsed...)
+	}
+	return messages, nil
+}
+
+// Watch 监听目录中的文件变化，嵌入式等非操作系统文件系统不支持监听
+func (s *FileSource) Watch(onChange func()) (io.Closer, error) {
+	if !isOSFS(s.loader.fsys) {
+		return nil, nil
+	}
+	return NewFileWatcher(s.loader.config.Path, func() error {
+		onChange()
+		return nil
+	}), nil
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// SQLSourceConfig 数据库消息来源配置
+type SQLSourceConfig struct {
+	// Table 消息表名，默认为 "i18n_messages"，直接拼接到 SQL 中，不能来自用户输入
+	Table string `yaml:"table" json:"table"`
+
+	// Placeholder 查询参数占位符，默认为 "?"（MySQL、SQLite），PostgreSQL 使用 "$1"
+	Placeholder string `yaml:"placeholder" json:"placeholder"`
+
+	// PollInterval 监听变化时轮询消息表的间隔，为 0 时不监听，只在重新加载时读取最新的消息
+	PollInterval time.Duration `yaml:"poll_interval" json:"poll_interval"`
+}
+
+// DefaultSQLTable 默认的消息表名
+const DefaultSQLTable = "i18n_messages"
+
+// SQLSchema 消息表结构（SQLite、PostgreSQL、MySQL 通用），%s 为表名，每行为一种语言中的一条消息，
+// 复数形式为空时不使用，id 可以带命名空间（errors:NOT_FOUND）或变体（WELCOME#female）
+//
+//	db.Exec(fmt.Sprintf(SQLSchema, DefaultSQLTable))
+const SQLSchema = `CREATE TABLE IF NOT EXISTS %s (
+	lang        VARCHAR(35)  NOT NULL,
+	id          VARCHAR(255) NOT NULL,
+	description TEXT,
+	zero        TEXT,
+	one         TEXT,
+	two         TEXT,
+	few         TEXT,
+	many        TEXT,
+	other       TEXT,
+	PRIMARY KEY (lang, id)
+)`
+
+// SQLSource 数据库消息来源，从 SQLSchema 结构的表中读取消息
+type SQLSource struct {
+	db     *sql.DB
+	config SQLSourceConfig
+}
+
+// NewSQLSource 创建数据库消息来源
+func NewSQLSource(db *sql.DB, config SQLSourceConfig) *SQLSource {
+	if config.Table == "" {
+		config.Table = DefaultSQLTable
+	}
+	if config.Placeholder == "" {
+		config.Placeholder = "?"
+	}
+	return &SQLSource{db: db, config: config}
+}
+
+// String 实现 fmt.Stringer
+func (s *SQLSource) String() string {
+	return "sql:" + s.config.Table
+}
+
+// Languages 返回消息表中的语言，按语言代码排序
+func (s *SQLSource) Languages() ([]string, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT DISTINCT lang FROM %s ORDER BY lang", s.config.Table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query languages: %w", err)
+	}
+	defer rows.Close()
+
+	var languages []string
+	for rows.Next() {
+		var lang string
+		if err := rows.Scan(&lang); err != nil {
+			return nil, fmt.Errorf("failed to scan language: %w", err)
+		}
+		languages = append(languages, lang)
+	}
+	return languages, rows.Err()
+}
+
+// LoadMessages 加载一种语言的所有消息，没有任何复数形式的行会被忽略
+func (s *SQLSource) LoadMessages(lang string) ([]*i18n.Message, error) {
+	query := fmt.Sprintf("SELECT id, description, zero, one, two, few, many, other FROM %s WHERE lang = %s ORDER BY id",
+		s.config.Table, s.config.Placeholder)
+	rows, err := s.db.Query(query, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*i18n.Message
+	for rows.Next() {
+		var id string
+		var description, zero, one, two, few, many, other sql.NullString
+		if err := rows.Scan(&id, &description, &zero, &one, &two, &few, &many, &other); err != nil {
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+
+		m := &i18n.Message{
+			ID:          id,
+			Description: description.String,
+			Zero:        zero.String,
+			One:         one.String,
+			Two:         two.String,
+			Few:         few.String,
+			Many:        many.String,
+			Other:       other.String,
+		}
+		if len(MessageForms(m)) > 0 {
+			messages = append(messages, m)
+		}
+	}
+	return messages, rows.Err()
+}
+
+// Watch 按 PollInterval 轮询消息表，内容变化时调用 onChange，PollInterval 为 0 时不监听
+func (s *SQLSource) Watch(onChange func()) (io.Closer, error) {
+	if s.config.PollInterval <= 0 {
+		return nil, nil
+	}
+
+	checksum, err := s.checksum()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &sqlPoller{done: make(chan struct{})}
+	go p.poll(s, checksum, onChange)
+	return p, nil
+}
+
+// checksum 计算消息表内容的校验和
+func (s *SQLSource) checksum() (string, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT lang, id, description, zero, one, two, few, many, other FROM %s ORDER BY lang, id", s.config.Table))
+	if err != nil {
+		return "", fmt.Errorf("failed to query messages: %w", err)
+	}
+	defer rows.Close()
+
+	hash := sha256.New()
+	values := make([]sql.NullString, 9)
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return "", fmt.Errorf("failed to scan message: %w", err)
+		}
+		for _, v := range values {
+			// 区分 NULL 和空字符串，带引号的文本保证字段边界不会混淆
+			fmt.Fprintf(hash, "%t%q", v.Valid, v.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// sqlPoller 数据库消息来源的轮询器
+type sqlPoller struct {
+	done chan struct{}
+	once sync.Once
+}
+
+// poll 定期计算校验和，与上次不同时调用 onChange
+func (p *sqlPoller) poll(s *SQLSource, checksum string, onChange func()) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			current, err := s.checksum()
+			if err != nil {
+				log.Printf("[i18n] Failed to poll %s: %v", s, err)
+				continue
+			}
+			if current != checksum {
+				checksum = current
+				onChange()
+			}
+		}
+	}
+}
+
+// Close 停止轮询
+func (p *sqlPoller) Close() error {
+	p.once.Do(func() {
+		close(p.done)
+	})
+	return nil
+}
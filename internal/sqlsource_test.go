@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	_ "modernc.org/sqlite"
+)
+
+// openTestDB 创建带消息表的 SQLite 数据库
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "i18n.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(fmt.Sprintf(SQLSchema, DefaultSQLTable))
+	require.NoError(t, err)
+	return db
+}
+
+func TestSQLSource(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`INSERT INTO i18n_messages (lang, id, description, one, other) VALUES
+		('en', 'FILES', 'File count', '{{.Count}} file', '{{.Count}} files'),
+		('en', 'EMPTY', NULL, NULL, NULL),
+		('de', 'FILES', NULL, '{{.Count}} Datei', '{{.Count}} Dateien')`)
+	require.NoError(t, err)
+
+	source := NewSQLSource(db, SQLSourceConfig{})
+	assert.Equal(t, "sql:i18n_messages", SourceName(source))
+
+	languages, err := source.Languages()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"de", "en"}, languages)
+
+	messages, err := source.LoadMessages("en")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, &i18n.Message{ID: "FILES", Description: "File count", One: "{{.Count}} file", Other: "{{.Count}} files"}, messages[0])
+
+	// 与语言文件组合，数据库中的消息覆盖语言文件中的同名消息
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{"FILES": {"other": "old", "description": "From file", "owner": "docs"}, "TITLE": "Title"}`,
+	})
+	bundle := i18n.NewBundle(language.English)
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir, Sources: []MessageSource{source}}, bundle)
+	require.NoError(t, loader.LoadLocales())
+	assert.Equal(t, []string{"en", "de"}, loader.Languages())
+	assert.Len(t, loader.Messages("en"), 2)
+
+	translated, err := i18n.NewLocalizer(bundle, "de").Localize(&i18n.LocalizeConfig{MessageID: "FILES", PluralCount: 1, TemplateData: map[string]int{"Count": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, "1 Datei", translated)
+
+	info, ok := loader.MessageInfo("en", "FILES")
+	require.True(t, ok)
+	assert.Equal(t, "File count", info.Description)
+	assert.Equal(t, "docs", info.Owner)
+
+	// 数据库覆盖语言文件中的消息不算重复定义
+	assert.Empty(t, loader.Duplicates())
+	assert.Empty(t, loader.GetStats().Duplicates)
+
+	// 查询失败时记录到 LoadError
+	broken := NewSQLSource(db, SQLSourceConfig{Table: "missing"})
+	loader = NewLocaleLoader(LocaleLoaderConfig{Path: dir, Sources: []MessageSource{broken}}, i18n.NewBundle(language.English))
+	var loadErr *LoadError
+	require.ErrorAs(t, loader.LoadLocales(), &loadErr)
+	assert.Equal(t, "sql:missing", loadErr.Files[0].Path)
+}
+
+func TestSQLSourceWatch(t *testing.T) {
+	db := openTestDB(t)
+
+	closer, err := NewSQLSource(db, SQLSourceConfig{}).Watch(func() {})
+	require.NoError(t, err)
+	assert.Nil(t, closer)
+
+	changes := make(chan struct{}, 1)
+	closer, err = NewSQLSource(db, SQLSourceConfig{PollInterval: 5 * time.Millisecond}).Watch(func() {
+		changes <- struct{}{}
+	})
+	require.NoError(t, err)
+	defer closer.Close()
+
+	// 每次修改都会被检测到
+	for _, stmt := range []string{
+		`INSERT INTO i18n_messages (lang, id, other) VALUES ('en', 'HELLO', 'Hello')`,
+		`DELETE FROM i18n_messages WHERE id = 'HELLO'`,
+	} {
+		_, err = db.Exec(stmt)
+		require.NoError(t, err)
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("change was not detected: %s", stmt)
+		}
+	}
+	require.NoError(t, closer.Close())
+}
+
+func TestFileSource(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en/common.json": `{"WELCOME": "Welcome"}`,
+		"en/errors.json": `{"NOT_FOUND": "Not found"}`,
+		"fr/common.json": `{"WELCOME": "Bienvenue"}`,
+	})
+
+	source := NewFileSource(LocaleLoaderConfig{Path: dir, Namespaces: true})
+	languages, err := source.Languages()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"en", "fr"}, languages)
+
+	messages, err := source.LoadMessages("en")
+	require.NoError(t, err)
+	var ids []string
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+	assert.ElementsMatch(t, []string{"common:WELCOME", "errors:NOT_FOUND"}, ids)
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// TemplateCheckMode 加载时模板检查的处理方式
+type TemplateCheckMode string
+
+const (
+	// TemplateCheckOff 不检查
+	TemplateCheckOff TemplateCheckMode = "off"
+	// TemplateCheckWarn 检查并记录日志
+	TemplateCheckWarn TemplateCheckMode = "warn"
+	// TemplateCheckFail 检查并返回 *TemplateCheckError
+	TemplateCheckFail TemplateCheckMode = "error"
+)
+
+// ParseTemplateCheckMode 解析模板检查方式，空字符串视为 TemplateCheckOff
+func ParseTemplateCheckMode(s string) (TemplateCheckMode, error) {
+	switch mode := TemplateCheckMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return TemplateCheckOff, nil
+	case TemplateCheckOff, TemplateCheckWarn, TemplateCheckFail:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported template check mode: %s", s)
+	}
+}
+
+// CheckTemplates 编译最近一次加载的所有语言中每条消息的每个复数形式，source 为源语言：
+// 模板无法解析（语法错误、使用了 funcs 中没有的函数）时报告 IssueTemplate；
+// 使用的占位符集合与源语言中的同一消息不同时报告 IssuePlaceholderMismatch
+// 结果按消息ID、语言排序
+func (l *LocaleLoader) CheckTemplates(source string, funcs template.FuncMap) []MessageIssue {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var issues []MessageIssue
+	for _, lang := range l.sourceFirstLanguages(source) {
+		isSource := strings.EqualFold(lang, source)
+		for id, m := range l.messages[lang] {
+			if issue, ok := l.compileMessage(lang, m, funcs); !ok {
+				issues = append(issues, issue)
+				continue
+			}
+			if isSource {
+				continue
+			}
+			if src := l.sourceMessage(source, id); src != nil {
+				if issue, ok := l.comparePlaceholders(lang, m, src); !ok {
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].ID != issues[j].ID {
+			return issues[i].ID < issues[j].ID
+		}
+		return issues[i].Lang < issues[j].Lang
+	})
+	return issues
+}
+
+// compileMessage 编译消息的所有复数形式，返回第一个错误
+func (l *LocaleLoader) compileMessage(lang string, m *i18n.Message, funcs template.FuncMap) (MessageIssue, bool) {
+	for _, form := range MessageForms(m) {
+		var err error
+		if l.config.MessageFormat == ICUFormat {
+			_, err = ParseICU(form.Value)
+		} else {
+			_, err = template.New(m.ID).Delims(m.LeftDelim, m.RightDelim).Funcs(funcs).Parse(form.Value)
+		}
+		if err != nil {
+			return MessageIssue{
+				Lang:    lang,
+				ID:      m.ID,
+				Kind:    IssueTemplate,
+				Message: fmt.Sprintf("%s form: %v", form.Name, err),
+			}, false
+		}
+	}
+	return MessageIssue{}, true
+}
+
+// comparePlaceholders 比较翻译与源语言消息使用的占位符（所有复数形式合并计算）
+func (l *LocaleLoader) comparePlaceholders(lang string, m, src *i18n.Message) (MessageIssue, bool) {
+	got := messagePlaceholders(m, l.config.MessageFormat)
+	want := messagePlaceholders(src, l.config.MessageFormat)
+
+	var missing, extra []string
+	for _, name := range want {
+		if !containsString(got, name) {
+			missing = append(missing, name)
+		}
+	}
+	for _, name := range got {
+		if !containsString(want, name) {
+			extra = append(extra, name)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return MessageIssue{}, true
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, "extra "+strings.Join(extra, ", "))
+	}
+	return MessageIssue{
+		Lang:    lang,
+		ID:      m.ID,
+		Kind:    IssuePlaceholderMismatch,
+		Message: "placeholders differ from the source language: " + strings.Join(parts, "; "),
+	}, false
+}
+
+// TemplateCheckError 加载时模板检查发现的问题
+type TemplateCheckError struct {
+	Issues []MessageIssue
+}
+
+// Error 实现 error 接口
+func (e *TemplateCheckError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "template check found %d issue(s)", len(e.Issues))
+	for _, issue := range e.Issues {
+		b.WriteString("\n  ")
+		b.WriteString(issue.Error())
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseTemplateCheckMode(t *testing.T) {
+	mode, err := ParseTemplateCheckMode("")
+	require.NoError(t, err)
+	assert.Equal(t, TemplateCheckOff, mode)
+
+	mode, err = ParseTemplateCheckMode(" Error ")
+	require.NoError(t, err)
+	assert.Equal(t, TemplateCheckFail, mode)
+
+	_, err = ParseTemplateCheckMode("panic")
+	assert.Error(t, err)
+}
+
+func TestCheckTemplates(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{
+			"WELCOME": "Welcome, {{.Name}}",
+			"FILES": {"one": "One file", "other": "{{num .Count}} files"},
+			"TOTAL": "{{.Count}} of {{.Total}}"
+		}`,
+		"de.json": `{
+			"WELCOME": "Willkommen, {{.Name}",
+			"FILES": {"one": "Eine Datei", "other": "{{num .Count}} Dateien"},
+			"TOTAL": "{{.Count}} von {{.Sum}}",
+			"EXTRA": "{{upper .Name}}"
+		}`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+
+	funcs := template.FuncMap{"num": func(v interface{}) string { return "" }}
+	var got []string
+	for _, issue := range loader.CheckTemplates("en", funcs) {
+		got = append(got, issue.Error())
+	}
+	require.Len(t, got, 3)
+	assert.Contains(t, got[0], `EXTRA (de): other form: template: EXTRA:1: function "upper" not defined`)
+	assert.Equal(t, "TOTAL (de): placeholders differ from the source language: missing Total; extra Sum", got[1])
+	assert.Contains(t, got[2], "WELCOME (de): other form: template: WELCOME:1:")
+}
+
+func TestCheckTemplatesICU(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{"FILES": "{count, plural, one {# file in {folder}} other {# files in {folder}}}"}`,
+		"de.json": `{"FILES": "{count, plural, one {# Datei} other {# Dateien}}"}`,
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir, MessageFormat: ICUFormat}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+
+	issues := loader.CheckTemplates("en", nil)
+	require.Len(t, issues, 1)
+	assert.Equal(t, IssuePlaceholderMismatch, issues[0].Kind)
+	assert.Equal(t, "de", issues[0].Lang)
+}
@@ -0,0 +1,448 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// ListStyle 列表的连接方式
+type ListStyle string
+
+const (
+	ListAnd ListStyle = "and" // A, B, and C；A、B和C
+	ListOr  ListStyle = "or"  // A, B, or C；A、B或C
+)
+
+// ParseListStyle 解析列表样式，空字符串为 and
+func ParseListStyle(s string) (ListStyle, error) {
+	switch style := ListStyle(strings.ToLower(s)); style {
+	case "":
+		return ListAnd, nil
+	case ListAnd, ListOr:
+		return style, nil
+	}
+	return "", fmt.Errorf("invalid list style %q", s)
+}
+
+// FormatList 按语言连接列表，如 en: "A, B, and C"；en-GB: "A, B and C"；zh-CN: "A、B和C"
+func FormatList(tag language.Tag, items []string, style ListStyle) string {
+	l := unitLocaleOf(tag)
+	pattern := l.and
+	if style == ListOr {
+		pattern = l.or
+	}
+
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return listJoin(pattern.two, items[0], items[1])
+	}
+
+	// CLDR 列表模式从后向前组合：end 连接最后两项，start 连接前面的每一项
+	result := listJoin(pattern.end, items[len(items)-2], items[len(items)-1])
+	for i := len(items) - 3; i >= 0; i-- {
+		result = listJoin(pattern.start, items[i], result)
+	}
+	return result
+}
+
+func listJoin(pattern, first, second string) string {
+	return strings.NewReplacer("{0}", first, "{1}", second).Replace(pattern)
+}
+
+// FormatUnit 按语言格式化带单位的数量，单位名称按 CLDR 复数规则变化，如 en: "5 kilometers"；ru: "5 километров"
+// unit 为 second、minute、hour、day、week、month、year、meter、kilometer、centimeter、mile、
+// gram、kilogram、liter、byte、kilobyte、megabyte、gigabyte
+func FormatUnit(tag language.Tag, v interface{}, unit string) (string, error) {
+	forms, ok := unitLocaleOf(tag).units[unit]
+	if !ok {
+		return "", fmt.Errorf("unknown unit %q", unit)
+	}
+	n, err := icuNumber(v)
+	if err != nil {
+		return "", err
+	}
+	formatted, err := FormatNumber(tag, v)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(selectPluralForm(tag, forms, n), "{0}", formatted, 1), nil
+}
+
+// durationUnits 时长拆分使用的单位，从大到小
+var durationUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// FormatDuration 按语言格式化时长，如 en: "2 hours 5 minutes"；zh-CN: "2小时5分钟"
+// 按天、小时、分钟、秒拆分并省略为零的单位，不足一秒的部分舍去；maxUnits 大于 0 时只输出最大的几个单位
+func FormatDuration(tag language.Tag, d time.Duration, maxUnits int) string {
+	if d < 0 {
+		d = -d
+	}
+
+	var parts []string
+	for _, unit := range durationUnits {
+		if maxUnits > 0 && len(parts) == maxUnits {
+			break
+		}
+		n := d / unit.size
+		if n == 0 {
+			continue
+		}
+		d -= n * unit.size
+		part, _ := FormatUnit(tag, int64(n), unit.name)
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		part, _ := FormatUnit(tag, 0, "second")
+		return part
+	}
+	return strings.Join(parts, unitLocaleOf(tag).unitSeparator)
+}
+
+// UnitFuncs 列表、单位和时长的模板函数
+//
+//	{{list .Names}}  {{list .Names "or"}}      A, B, and C / A, B, or C
+//	{{unit .Distance "kilometer"}}             5 kilometers
+//	{{duration .Elapsed}}  {{duration .Elapsed 2}}  2 hours 5 minutes
+func UnitFuncs(tag language.Tag) template.FuncMap {
+	return template.FuncMap{
+		"list": func(items interface{}, style ...string) (string, error) {
+			s, err := ParseListStyle(strings.Join(style, ""))
+			if err != nil {
+				return "", err
+			}
+			strs, err := listItems(items)
+			if err != nil {
+				return "", err
+			}
+			return FormatList(tag, strs, s), nil
+		},
+		"unit": func(v interface{}, unit string) (string, error) {
+			return FormatUnit(tag, v, unit)
+		},
+		"duration": func(d time.Duration, maxUnits ...int) string {
+			max := 0
+			if len(maxUnits) > 0 {
+				max = maxUnits[0]
+			}
+			return FormatDuration(tag, d, max)
+		},
+	}
+}
+
+// listItems 将切片或数组参数转换为字符串列表
+func listItems(items interface{}) ([]string, error) {
+	if strs, ok := items.([]string); ok {
+		return strs, nil
+	}
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %T", items)
+	}
+	strs := make([]string, v.Len())
+	for i := range strs {
+		strs[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strs, nil
+}
+
+// pluralFormOrder 单位数据中各位置对应的复数形式，按形式的数量确定；语言的规则选择了未列出的形式时使用 other
+var pluralFormOrder = map[int][]string{
+	1: {"other"},
+	2: {"one", "other"},
+	3: {"one", "two", "other"},
+	4: {"one", "few", "many", "other"},
+	6: {"zero", "one", "two", "few", "many", "other"},
+}
+
+// selectPluralForm 按语言的复数规则选择形式，forms 的顺序见 pluralFormOrder
+func selectPluralForm(tag language.Tag, forms []string, n float64) string {
+	form := PluralForm(tag, n)
+	for i, name := range pluralFormOrder[len(forms)] {
+		if name == form {
+			return forms[i]
+		}
+	}
+	return forms[len(forms)-1]
+}
+
+// pluralRuleMessage 每个复数形式的文本即形式名称，翻译后得到数量对应的形式
+var pluralRuleMessage = &i18n.Message{ID: "plural", Zero: "zero", One: "one", Two: "two", Few: "few", Many: "many", Other: "other"}
+
+// pluralLocalizers 语言 -> 只包含 pluralRuleMessage 的 *i18n.Localizer，没有复数规则的语言为 nil
+var pluralLocalizers sync.Map
+
+// PluralForm 返回数量在语言中对应的复数形式（zero、one、two、few、many、other），
+// 与翻译消息时使用相同的 go-i18n 复数规则；浮点数按最短的十进制表示计算（1.0 与 1 相同）；
+// 语言没有复数规则或数量无效时返回 other
+func PluralForm(tag language.Tag, count interface{}) string {
+	switch n := count.(type) {
+	case float64:
+		count = strconv.FormatFloat(n, 'f', -1, 64)
+	case float32:
+		count = strconv.FormatFloat(float64(n), 'f', -1, 32)
+	}
+
+	key := tag.String()
+	cached, ok := pluralLocalizers.Load(key)
+	if !ok {
+		var loc *i18n.Localizer
+		bundle := i18n.NewBundle(tag)
+		if err := bundle.AddMessages(tag, pluralRuleMessage); err == nil {
+			loc = i18n.NewLocalizer(bundle, key)
+		}
+		cached, _ = pluralLocalizers.LoadOrStore(key, loc)
+	}
+
+	loc := cached.(*i18n.Localizer)
+	if loc == nil {
+		return "other"
+	}
+	form, err := loc.Localize(&i18n.LocalizeConfig{MessageID: pluralRuleMessage.ID, PluralCount: count})
+	if err != nil {
+		return "other"
+	}
+	return form
+}
+
+// listPattern CLDR 列表模式，{0} 和 {1} 为前后两部分；start 同时用作 middle（所支持的语言中两者相同）
+type listPattern struct {
+	two, start, end string
+}
+
+// unitLocale 语言的列表、单位数据（取自 CLDR）
+type unitLocale struct {
+	and, or       listPattern
+	unitSeparator string              // 时长各单位之间的分隔符
+	units         map[string][]string // 单位 -> 复数形式，{0} 为数量
+}
+
+// unitLocaleOf 按语言及其 CLDR 父语言（如 en-GB -> en-001 -> en、zh-HK -> zh-Hant）查找数据，没有时使用英语
+func unitLocaleOf(tag language.Tag) *unitLocale {
+	for t := tag; !t.IsRoot(); t = t.Parent() {
+		if l, ok := unitLocales[t.String()]; ok {
+			return l
+		}
+	}
+	return unitLocales["en"]
+}
+
+var englishUnits = map[string][]string{
+	"second": {"{0} second", "{0} seconds"}, "minute": {"{0} minute", "{0} minutes"},
+	"hour": {"{0} hour", "{0} hours"}, "day": {"{0} day", "{0} days"},
+	"week": {"{0} week", "{0} weeks"}, "month": {"{0} month", "{0} months"},
+	"year": {"{0} year", "{0} years"}, "meter": {"{0} meter", "{0} meters"},
+	"kilometer": {"{0} kilometer", "{0} kilometers"}, "centimeter": {"{0} centimeter", "{0} centimeters"},
+	"mile": {"{0} mile", "{0} miles"}, "gram": {"{0} gram", "{0} grams"},
+	"kilogram": {"{0} kilogram", "{0} kilograms"}, "liter": {"{0} liter", "{0} liters"},
+	"byte": {"{0} byte", "{0} bytes"}, "kilobyte": {"{0} kilobyte", "{0} kilobytes"},
+	"megabyte": {"{0} megabyte", "{0} megabytes"}, "gigabyte": {"{0} gigabyte", "{0} gigabytes"},
+}
+
+// britishUnits en-001（英式英语）的单位，拼写为 metre、litre，其余与 en 相同
+var britishUnits = func() map[string][]string {
+	units := make(map[string][]string, len(englishUnits))
+	for unit, forms := range englishUnits {
+		units[unit] = forms
+	}
+	units["meter"] = []string{"{0} metre", "{0} metres"}
+	units["kilometer"] = []string{"{0} kilometre", "{0} kilometres"}
+	units["centimeter"] = []string{"{0} centimetre", "{0} centimetres"}
+	units["liter"] = []string{"{0} litre", "{0} litres"}
+	return units
+}()
+
+var unitLocales = map[string]*unitLocale{
+	"en": {
+		and:           listPattern{"{0} and {1}", "{0}, {1}", "{0}, and {1}"},
+		or:            listPattern{"{0} or {1}", "{0}, {1}", "{0}, or {1}"},
+		unitSeparator: " ",
+		units:         englishUnits,
+	},
+	"en-001": {
+		and:           listPattern{"{0} and {1}", "{0}, {1}", "{0} and {1}"},
+		or:            listPattern{"{0} or {1}", "{0}, {1}", "{0} or {1}"},
+		unitSeparator: " ",
+		units:         britishUnits,
+	},
+	"zh": {
+		and: listPattern{"{0}和{1}", "{0}、{1}", "{0}和{1}"},
+		or:  listPattern{"{0}或{1}", "{0}、{1}", "{0}或{1}"},
+		units: map[string][]string{
+			"second": {"{0}秒钟"}, "minute": {"{0}分钟"}, "hour": {"{0}小时"}, "day": {"{0}天"},
+			"week": {"{0}周"}, "month": {"{0}个月"}, "year": {"{0}年"}, "meter": {"{0}米"},
+			"kilometer": {"{0}公里"}, "centimeter": {"{0}厘米"}, "mile": {"{0}英里"}, "gram": {"{0}克"},
+			"kilogram": {"{0}千克"}, "liter": {"{0}升"}, "byte": {"{0}字节"}, "kilobyte": {"{0}千字节"},
+			"megabyte": {"{0}兆字节"}, "gigabyte": {"{0}吉字节"},
+		},
+	},
+	"zh-Hant": {
+		and:           listPattern{"{0}和{1}", "{0}、{1}", "{0}和{1}"},
+		or:            listPattern{"{0}或{1}", "{0}、{1}", "{0}或{1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second": {"{0} 秒"}, "minute": {"{0} 分鐘"}, "hour": {"{0} 小時"}, "day": {"{0} 天"},
+			"week": {"{0} 週"}, "month": {"{0} 個月"}, "year": {"{0} 年"}, "meter": {"{0} 公尺"},
+			"kilometer": {"{0} 公里"}, "centimeter": {"{0} 公分"}, "mile": {"{0} 英里"}, "gram": {"{0} 克"},
+			"kilogram": {"{0} 公斤"}, "liter": {"{0} 公升"}, "byte": {"{0} 位元組"}, "kilobyte": {"{0} 千位元組"},
+			"megabyte": {"{0} 百萬位元組"}, "gigabyte": {"{0} 十億位元組"},
+		},
+	},
+	"ja": {
+		and:           listPattern{"{0}、{1}", "{0}、{1}", "{0}、{1}"},
+		or:            listPattern{"{0}または{1}", "{0}、{1}", "{0}、または{1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second": {"{0} 秒"}, "minute": {"{0} 分"}, "hour": {"{0} 時間"}, "day": {"{0} 日"},
+			"week": {"{0} 週間"}, "month": {"{0} か月"}, "year": {"{0} 年"}, "meter": {"{0} メートル"},
+			"kilometer": {"{0} キロメートル"}, "centimeter": {"{0} センチメートル"}, "mile": {"{0} マイル"}, "gram": {"{0} グラム"},
+			"kilogram": {"{0} キログラム"}, "liter": {"{0} リットル"}, "byte": {"{0} バイト"}, "kilobyte": {"{0} キロバイト"},
+			"megabyte": {"{0} メガバイト"}, "gigabyte": {"{0} ギガバイト"},
+		},
+	},
+	"ko": {
+		and:           listPattern{"{0} 및 {1}", "{0}, {1}", "{0} 및 {1}"},
+		or:            listPattern{"{0} 또는 {1}", "{0}, {1}", "{0} 또는 {1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second": {"{0}초"}, "minute": {"{0}분"}, "hour": {"{0}시간"}, "day": {"{0}일"},
+			"week": {"{0}주"}, "month": {"{0}개월"}, "year": {"{0}년"}, "meter": {"{0}미터"},
+			"kilometer": {"{0}킬로미터"}, "centimeter": {"{0}센티미터"}, "mile": {"{0}마일"}, "gram": {"{0}그램"},
+			"kilogram": {"{0}킬로그램"}, "liter": {"{0}리터"}, "byte": {"{0}바이트"}, "kilobyte": {"{0}킬로바이트"},
+			"megabyte": {"{0}메가바이트"}, "gigabyte": {"{0}기가바이트"},
+		},
+	},
+	"de": {
+		and:           listPattern{"{0} und {1}", "{0}, {1}", "{0} und {1}"},
+		or:            listPattern{"{0} oder {1}", "{0}, {1}", "{0} oder {1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second": {"{0} Sekunde", "{0} Sekunden"}, "minute": {"{0} Minute", "{0} Minuten"},
+			"hour": {"{0} Stunde", "{0} Stunden"}, "day": {"{0} Tag", "{0} Tage"},
+			"week": {"{0} Woche", "{0} Wochen"}, "month": {"{0} Monat", "{0} Monate"},
+			"year": {"{0} Jahr", "{0} Jahre"}, "meter": {"{0} Meter"},
+			"kilometer": {"{0} Kilometer"}, "centimeter": {"{0} Zentimeter"},
+			"mile": {"{0} Meile", "{0} Meilen"}, "gram": {"{0} Gramm"},
+			"kilogram": {"{0} Kilogramm"}, "liter": {"{0} Liter"},
+			"byte": {"{0} Byte"}, "kilobyte": {"{0} Kilobyte"},
+			"megabyte": {"{0} Megabyte"}, "gigabyte": {"{0} Gigabyte"},
+		},
+	},
+	"fr": {
+		and:           listPattern{"{0} et {1}", "{0}, {1}", "{0} et {1}"},
+		or:            listPattern{"{0} ou {1}", "{0}, {1}", "{0} ou {1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second": {"{0} seconde", "{0} secondes"}, "minute": {"{0} minute", "{0} minutes"},
+			"hour": {"{0} heure", "{0} heures"}, "day": {"{0} jour", "{0} jours"},
+			"week": {"{0} semaine", "{0} semaines"}, "month": {"{0} mois"},
+			"year": {"{0} an", "{0} ans"}, "meter": {"{0} mètre", "{0} mètres"},
+			"kilometer": {"{0} kilomètre", "{0} kilomètres"}, "centimeter": {"{0} centimètre", "{0} centimètres"},
+			"mile": {"{0} mile", "{0} miles"}, "gram": {"{0} gramme", "{0} grammes"},
+			"kilogram": {"{0} kilogramme", "{0} kilogrammes"}, "liter": {"{0} litre", "{0} litres"},
+			"byte": {"{0} octet", "{0} octets"}, "kilobyte": {"{0} kilooctet", "{0} kilooctets"},
+			"megabyte": {"{0} mégaoctet", "{0} mégaoctets"}, "gigabyte": {"{0} gigaoctet", "{0} gigaoctets"},
+		},
+	},
+	"es": {
+		and:           listPattern{"{0} y {1}", "{0}, {1}", "{0} y {1}"},
+		or:            listPattern{"{0} o {1}", "{0}, {1}", "{0} o {1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second": {"{0} segundo", "{0} segundos"}, "minute": {"{0} minuto", "{0} minutos"},
+			"hour": {"{0} hora", "{0} horas"}, "day": {"{0} día", "{0} días"},
+			"week": {"{0} semana", "{0} semanas"}, "month": {"{0} mes", "{0} meses"},
+			"year": {"{0} año", "{0} años"}, "meter": {"{0} metro", "{0} metros"},
+			"kilometer": {"{0} kilómetro", "{0} kilómetros"}, "centimeter": {"{0} centímetro", "{0} centímetros"},
+			"mile": {"{0} milla", "{0} millas"}, "gram": {"{0} gramo", "{0} gramos"},
+			"kilogram": {"{0} kilogramo", "{0} kilogramos"}, "liter": {"{0} litro", "{0} litros"},
+			"byte": {"{0} byte", "{0} bytes"}, "kilobyte": {"{0} kilobyte", "{0} kilobytes"},
+			"megabyte": {"{0} megabyte", "{0} megabytes"}, "gigabyte": {"{0} gigabyte", "{0} gigabytes"},
+		},
+	},
+	"ru": {
+		and:           listPattern{"{0} и {1}", "{0}, {1}", "{0} и {1}"},
+		or:            listPattern{"{0} или {1}", "{0}, {1}", "{0} или {1}"},
+		unitSeparator: " ",
+		units: map[string][]string{
+			"second":     {"{0} секунда", "{0} секунды", "{0} секунд", "{0} секунды"},
+			"minute":     {"{0} минута", "{0} минуты", "{0} минут", "{0} минуты"},
+			"hour":       {"{0} час", "{0} часа", "{0} часов", "{0} часа"},
+			"day":        {"{0} день", "{0} дня", "{0} дней", "{0} дня"},
+			"week":       {"{0} неделя", "{0} недели", "{0} недель", "{0} недели"},
+			"month":      {"{0} месяц", "{0} месяца", "{0} месяцев", "{0} месяца"},
+			"year":       {"{0} год", "{0} года", "{0} лет", "{0} года"},
+			"meter":      {"{0} метр", "{0} метра", "{0} метров", "{0} метра"},
+			"kilometer":  {"{0} километр", "{0} километра", "{0} километров", "{0} километра"},
+			"centimeter": {"{0} сантиметр", "{0} сантиметра", "{0} сантиметров", "{0} сантиметра"},
+			"mile":       {"{0} миля", "{0} мили", "{0} миль", "{0} мили"},
+			"gram":       {"{0} грамм", "{0} грамма", "{0} граммов", "{0} грамма"},
+			"kilogram":   {"{0} килограмм", "{0} килограмма", "{0} килограммов", "{0} килограмма"},
+			"liter":      {"{0} литр", "{0} литра", "{0} литров", "{0} литра"},
+			"byte":       {"{0} байт", "{0} байта", "{0} байт", "{0} байта"},
+			"kilobyte":   {"{0} килобайт", "{0} килобайта", "{0} килобайт", "{0} килобайта"},
+			"megabyte":   {"{0} мегабайт", "{0} мегабайта", "{0} мегабайт", "{0} мегабайта"},
+			"gigabyte":   {"{0} гигабайт", "{0} гигабайта", "{0} гигабайт", "{0} гигабайта"},
+		},
+	},
+	"ar": {
+		and:           listPattern{"{0} و{1}", "{0} و{1}", "{0} و{1}"},
+		or:            listPattern{"{0} أو {1}", "{0} أو {1}", "{0} أو {1}"},
+		unitSeparator: " و",
+		units: map[string][]string{
+			"second":     {"{0} ثانية", "ثانية", "ثانيتان", "{0} ثوانٍ", "{0} ثانية", "{0} ثانية"},
+			"minute":     {"{0} دقيقة", "دقيقة", "دقيقتان", "{0} دقائق", "{0} دقيقة", "{0} دقيقة"},
+			"hour":       {"{0} ساعة", "ساعة", "ساعتان", "{0} ساعات", "{0} ساعة", "{0} ساعة"},
+			"day":        {"{0} يوم", "يوم", "يومان", "{0} أيام", "{0} يومًا", "{0} يوم"},
+			"week":       {"{0} أسبوع", "أسبوع", "أسبوعان", "{0} أسابيع", "{0} أسبوعًا", "{0} أسبوع"},
+			"month":      {"{0} شهر", "شهر", "شهران", "{0} أشهر", "{0} شهرًا", "{0} شهر"},
+			"year":       {"{0} سنة", "سنة واحدة", "سنتان", "{0} سنوات", "{0} سنة", "{0} سنة"},
+			"meter":      {"{0} متر", "متر", "متران", "{0} أمتار", "{0} مترًا", "{0} متر"},
+			"kilometer":  {"{0} كيلومتر", "كيلومتر", "كيلومتران", "{0} كيلومترات", "{0} كيلومترًا", "{0} كيلومتر"},
+			"centimeter": {"{0} سنتيمتر", "سنتيمتر", "سنتيمتران", "{0} سنتيمترات", "{0} سنتيمترًا", "{0} سنتيمتر"},
+			"mile":       {"{0} ميل", "ميل", "ميلان", "{0} أميال", "{0} ميلًا", "{0} ميل"},
+			"gram":       {"{0} غرام", "غرام", "غرامان", "{0} غرامات", "{0} غرامًا", "{0} غرام"},
+			"kilogram":   {"{0} كيلوغرام", "كيلوغرام", "كيلوغرامان", "{0} كيلوغرامات", "{0} كيلوغرامًا", "{0} كيلوغرام"},
+			"liter":      {"{0} لتر", "لتر", "لتران", "{0} لترات", "{0} لترًا", "{0} لتر"},
+			"byte":       {"{0} بايت"},
+			"kilobyte":   {"{0} كيلوبايت"},
+			"megabyte":   {"{0} ميغابايت"},
+			"gigabyte":   {"{0} غيغابايت"},
+		},
+	},
+	"he": {
+		and:           listPattern{"{0} ו{1}", "{0}, {1}", "{0} ו{1}"},
+		or:            listPattern{"{0} או {1}", "{0}, {1}", "{0} או {1}"},
+		unitSeparator: " ו",
+		units: map[string][]string{
+			"second": {"שנייה", "שתי שניות", "{0} שניות"}, "minute": {"דקה", "שתי דקות", "{0} דקות"},
+			"hour": {"שעה", "שעתיים", "{0} שעות"}, "day": {"יום", "יומיים", "{0} ימים"},
+			"week": {"שבוע", "שבועיים", "{0} שבועות"}, "month": {"חודש", "חודשיים", "{0} חודשים"},
+			"year": {"שנה", "שנתיים", "{0} שנים"}, "meter": {"{0} מטר"},
+			"kilometer": {"{0} קילומטר"}, "centimeter": {"{0} סנטימטר"},
+			"mile": {"{0} מייל"}, "gram": {"{0} גרם"},
+			"kilogram": {"{0} קילוגרם"}, "liter": {"{0} ליטר"},
+			"byte": {"{0} בייט"}, "kilobyte": {"{0} קילובייט"},
+			"megabyte": {"{0} מגה-בייט"}, "gigabyte": {"{0} ג׳יגה-בייט"},
+		},
+	},
+}
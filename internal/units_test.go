@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestFormatList(t *testing.T) {
+	items := []string{"A", "B", "C"}
+	tests := []struct {
+		lang  string
+		items []string
+		style ListStyle
+		want  string
+	}{
+		{"en", items, ListAnd, "A, B, and C"},
+		{"en", items, ListOr, "A, B, or C"},
+		{"en", items[:2], ListAnd, "A and B"},
+		{"en", items[:1], ListAnd, "A"},
+		{"en", nil, ListAnd, ""},
+		{"en-GB", items, ListAnd, "A, B and C"},
+		{"zh-CN", items, ListAnd, "A、B和C"},
+		{"zh-TW", items, ListOr, "A、B或C"},
+		{"ja", items, ListOr, "A、B、またはC"},
+		{"de", []string{"A", "B", "C", "D"}, ListAnd, "A, B, C und D"},
+		{"pt-BR", items, ListAnd, "A, B, and C"},
+		{"ar", items, ListAnd, "A وB وC"},
+		{"he", items, ListOr, "A, B או C"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatList(language.MustParse(tt.lang), tt.items, tt.style), tt.lang)
+	}
+}
+
+func TestFormatUnit(t *testing.T) {
+	tests := []struct {
+		lang string
+		v    interface{}
+		unit string
+		want string
+	}{
+		{"en", 1, "kilometer", "1 kilometer"},
+		{"en", 1500, "kilometer", "1,500 kilometers"},
+		{"en", 1.5, "kilogram", "1.5 kilograms"},
+		{"en-GB", 5, "kilometer", "5 kilometres"},
+		{"en-AU", 2, "liter", "2 litres"},
+		{"fr", 0, "hour", "0 heure"},
+		{"de", 5, "day", "5 Tage"},
+		{"ru", 1, "kilometer", "1 километр"},
+		{"ru", 3, "kilometer", "3 километра"},
+		{"ru", 5, "kilometer", "5 километров"},
+		{"ru", 1.5, "kilometer", "1,5 километра"},
+		{"zh-CN", 5, "kilometer", "5公里"},
+		{"ko", 3, "megabyte", "3메가바이트"},
+		{"ar", 0, "day", "٠ يوم"},
+		{"ar", 1, "day", "يوم"},
+		{"ar", 2, "day", "يومان"},
+		{"ar", 3, "day", "٣ أيام"},
+		{"ar", 11, "day", "١١ يومًا"},
+		{"ar", 100, "day", "١٠٠ يوم"},
+		{"he", 1, "hour", "שעה"},
+		{"he", 2, "hour", "שעתיים"},
+		{"he", 2, "kilometer", "2 קילומטר"},
+		{"he", 5, "hour", "5 שעות"},
+	}
+	for _, tt := range tests {
+		got, err := FormatUnit(language.MustParse(tt.lang), tt.v, tt.unit)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.lang)
+	}
+
+	_, err := FormatUnit(language.English, 1, "parsec")
+	assert.Error(t, err)
+	_, err = FormatUnit(language.English, "abc", "meter")
+	assert.Error(t, err)
+}
+
+func TestFormatDuration(t *testing.T) {
+	d := 2*time.Hour + 5*time.Minute + 30*time.Second
+	assert.Equal(t, "2 hours 5 minutes 30 seconds", FormatDuration(language.English, d, 0))
+	assert.Equal(t, "2 hours 5 minutes", FormatDuration(language.English, d, 2))
+	assert.Equal(t, "1 day 1 hour", FormatDuration(language.English, 25*time.Hour, 0))
+	assert.Equal(t, "0 seconds", FormatDuration(language.English, 500*time.Millisecond, 0))
+	assert.Equal(t, "2小时5分钟", FormatDuration(language.SimplifiedChinese, d, 2))
+	assert.Equal(t, "2 часа 5 минут", FormatDuration(language.Russian, d, 2))
+	assert.Equal(t, "2 minutes", FormatDuration(language.English, -2*time.Minute, 0))
+	assert.Equal(t, "ساعتان و٥ دقائق", FormatDuration(language.Arabic, d, 2))
+	assert.Equal(t, "שעתיים ו5 דקות", FormatDuration(language.Hebrew, d, 2))
+}
+
+func TestPluralForm(t *testing.T) {
+	tests := []struct {
+		lang  string
+		count interface{}
+		want  string
+	}{
+		{"en", 1, "one"},
+		{"en", int64(1), "one"},
+		{"en", 1.0, "one"},
+		{"en", 1.5, "other"},
+		{"fr", 0, "one"},
+		{"ru", 22, "few"},
+		{"ar", 0, "zero"},
+		{"ar", 2, "two"},
+		{"ar", 11, "many"},
+		{"he", 2, "two"},
+		{"ja", 1, "other"},
+		{"tlh", 1, "other"}, // 没有复数规则的语言
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, PluralForm(language.Make(tt.lang), tt.count), "%s %T(%v)", tt.lang, tt.count, tt.count)
+	}
+}
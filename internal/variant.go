@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContextSeparator 消息ID与语境变体之间的分隔符，如 "WELCOME#female"、"INVITE#formal"
+const ContextSeparator = "#"
+
+// VariantID 返回消息在指定语境下的变体ID，variant 为空时返回原ID
+func VariantID(id, variant string) string {
+	if variant == "" {
+		return id
+	}
+	return id + ContextSeparator + variant
+}
+
+// messageContext 取出并删除数组形式语言文件中消息的 context 字段，该字段会合并到消息ID
+//
+//	[{"id": "WELCOME", "translation": "Welcome"},
+//	 {"id": "WELCOME", "context": "female", "translation": "Welcome, madam"}]
+//
+// 第二条消息的ID为 WELCOME#female
+func messageContext(fields map[string]interface{}) (string, error) {
+	var variant string
+	for key, value := range fields {
+		if !strings.EqualFold(key, "context") {
+			continue
+		}
+		delete(fields, key)
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("context must be a string, got %T", value)
+		}
+		variant = s
+	}
+	return variant, nil
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"log"
+	"os"
 	"path/filepath"
 
 	"github.com/fsnotify/fsnotify"
@@ -29,6 +30,17 @@ func NewFileWatcher(path string, reloadCallback func() error) FileWatcher {
 		return &NoOpWatcher{}
 	}
 
+	// 分层结构下同时监听语言目录
+	if entries, err := os.ReadDir(path); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				if err := watcher.Add(filepath.Join(path, entry.Name())); err != nil {
+					log.Printf("[i18n] Add watcher failed: %v", err)
+				}
+			}
+		}
+	}
+
 	fw := &fsnotifyWatcher{
 		watcher: watcher,
 		path:    path,
@@ -52,10 +64,20 @@ func (w *fsnotifyWatcher) watch(reloadCallback func() error) {
 				return
 			}
 
+			// 新建的语言目录加入监听
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.watcher.Add(event.Name); err != nil {
+						log.Printf("[i18n] Add watcher failed: %v", err)
+					}
+					continue
+				}
+			}
+
 			// 检查是否为写入或创建事件
 			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
-				// 检查是否为 JSON 文件
-				if filepath.Ext(event.Name) == ".json" {
+				// 检查是否为支持的语言文件格式
+				if IsLocaleFile(event.Name) {
 					log.Printf("[i18n] Reloading locales due to file change: %s", filepath.Base(event.Name))
 
 					// 执行重载回调
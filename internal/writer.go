@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// WriteMessages 将消息写回语言目录，按模块组织（键为模块名）
+// 扁平模式下所有模块写入 <lang>.json；分层模式下写入 <lang>/<module>.json，空模块名写入 common
+// 目标文件已存在时保留其格式和其他消息，同ID的消息被替换。返回写入的文件列表
+func (l *LocaleLoader) WriteMessages(lang string, byModule map[string][]*i18n.Message, dryRun bool) ([]string, error) {
+	if !isOSFS(l.fsys) {
+		return nil, fmt.Errorf("writing messages requires the OS filesystem")
+	}
+
+	mode := l.config.Mode
+	if mode == "" {
+		if detected, err := DetectLocaleModeFS(l.fsys, l.config.Path); err == nil {
+			mode = detected
+		} else {
+			mode = FlatMode
+		}
+	}
+
+	modules := make([]string, 0, len(byModule))
+	for module := range byModule {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	// 按目标文件分组
+	var targets []string
+	byTarget := make(map[string][]*i18n.Message)
+	for _, module := range modules {
+		var dir, name string
+		messages := byModule[module]
+		switch mode {
+		case FlatMode:
+			dir, name = l.config.Path, lang
+		case NestedMode:
+			if module == "" {
+				module = "common"
+			}
+			dir, name = l.join(l.config.Path, lang), module
+			messages = stripNamespace(module, messages)
+		default:
+			return nil, fmt.Errorf("unsupported locale mode: %s", mode)
+		}
+
+		target := l.findLocaleFile(dir, name)
+		if target == "" {
+			target = l.join(dir, name+".json")
+		}
+		if _, exists := byTarget[target]; !exists {
+			targets = append(targets, target)
+		}
+		byTarget[target] = append(byTarget[target], messages...)
+	}
+
+	for _, target := range targets {
+		if dryRun {
+			continue
+		}
+		if err := l.mergeLocaleFile(target, lang, byTarget[target]); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	return targets, nil
+}
+
+// stripNamespace 去掉消息ID中与模块相同的命名空间前缀（如 errors:NOT_FOUND 写入 errors 模块时为 NOT_FOUND）
+func stripNamespace(module string, messages []*i18n.Message) []*i18n.Message {
+	prefix := module + NamespaceSeparator
+	stripped := make([]*i18n.Message, len(messages))
+	for i, m := range messages {
+		if strings.HasPrefix(m.ID, prefix) {
+			copied := *m
+			copied.ID = strings.TrimPrefix(m.ID, prefix)
+			m = &copied
+		}
+		stripped[i] = m
+	}
+	return stripped
+}
+
+// mergeLocaleFile 将消息合并到语言文件，被替换的消息保留原有的元数据（max_length、placeholders、owner）
+func (l *LocaleLoader) mergeLocaleFile(filename, lang string, messages []*i18n.Message) error {
+	var merged []*i18n.Message
+	var metadata []*MessageMetadata
+	tree := false
+	if fileExists(l.fsys, filename) {
+		existing, existingMetadata, err := parseLocaleFileWithMetadata(l.fsys, filename, lang, l.config.KeySeparator)
+		if err != nil {
+			return err
+		}
+		merged, metadata = existing, existingMetadata
+
+		if tree, err = isTreeFile(l.fsys, filename); err != nil {
+			return err
+		}
+	}
+
+	index := make(map[string]int, len(merged))
+	for i, m := range merged {
+		index[m.ID] = i
+	}
+	for _, m := range messages {
+		if i, exists := index[m.ID]; exists {
+			merged[i] = m
+			continue
+		}
+		index[m.ID] = len(merged)
+		merged = append(merged, m)
+		metadata = append(metadata, nil)
+	}
+
+	// 保持原文件的组织形式
+	data, err := encodeLocaleFile(fileFormat(filename), tree, merged, metadata, l.config.KeySeparator)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// isTreeFile 检查语言文件是否为对象形式（树形或以消息ID为键），而不是消息数组
+func isTreeFile(fsys fs.FS, filename string) (bool, error) {
+	unmarshal, ok := unmarshalFuncs[fileFormat(filename)]
+	if !ok {
+		return false, nil
+	}
+
+	buf, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return false, err
+	}
+
+	var raw interface{}
+	if err := unmarshal(buf, &raw); err != nil {
+		return false, err
+	}
+	_, isTree := normalizeMap(raw).(map[string]interface{})
+	return isTree, nil
+}
@@ -0,0 +1,720 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// XLIFF 与消息的映射规则:
+//   - 每个模块对应一个 <file>（扁平模式下为 "messages"），每条消息对应一个翻译单元
+//   - 复数消息对应一个以消息ID命名的 <group>，按目标语言的每个复数形式拆分为以形式（one、other 等）命名的单元，
+//     因此消息ID中的变体（如 "ITEM#other"）不会与复数形式混淆
+//   - 消息描述写入 <note>
+
+// XLIFFState 翻译单元状态
+type XLIFFState string
+
+const (
+	// XLIFFStateNew 未翻译
+	XLIFFStateNew XLIFFState = "new"
+	// XLIFFStateTranslated 已翻译
+	XLIFFStateTranslated XLIFFState = "translated"
+	// XLIFFStateFinal 已审校定稿
+	XLIFFStateFinal XLIFFState = "final"
+)
+
+// xliffStateRank 状态的先后顺序，用于导入时按最低状态过滤
+var xliffStateRank = map[XLIFFState]int{
+	XLIFFStateNew:        0,
+	XLIFFStateTranslated: 1,
+	XLIFFStateFinal:      2,
+}
+
+// AtLeast 检查状态是否不低于指定状态
+func (s XLIFFState) AtLeast(min XLIFFState) bool {
+	return xliffStateRank[s] >= xliffStateRank[min]
+}
+
+// ParseXLIFFState 解析状态名称，同时接受 XLIFF 1.2 和 2.0 的状态值
+func ParseXLIFFState(s string) (XLIFFState, error) {
+	switch s {
+	case "new", "initial", "needs-translation", "needs-adaptation", "needs-l10n":
+		return XLIFFStateNew, nil
+	case "translated", "reviewed", "needs-review-translation", "needs-review-adaptation", "needs-review-l10n":
+		return XLIFFStateTranslated, nil
+	case "final", "signed-off":
+		return XLIFFStateFinal, nil
+	}
+	return "", fmt.Errorf("unknown xliff state: %s", s)
+}
+
+// XLIFF 版本
+const (
+	XLIFFVersion12 = "1.2"
+	XLIFFVersion20 = "2.0"
+)
+
+// XLIFF 命名空间，解析时不要求文件声明命名空间
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+)
+
+// flatModuleName 扁平模式下 <file> 的名称
+const flatModuleName = "messages"
+
+// XLIFFUnit 翻译单元
+type XLIFFUnit struct {
+	Module string
+	ID     string // 消息ID
+	Form   string // 复数形式（one/other 等），非复数消息为空
+	Source string
+	Target string
+	Note   string
+	State  XLIFFState
+}
+
+// XLIFFDocument XLIFF 文档
+type XLIFFDocument struct {
+	SourceLang string
+	TargetLang string
+	Units      []XLIFFUnit
+}
+
+// NewXLIFFDocument 根据源语言和目标语言的消息创建 XLIFF 文档
+// moduleOf 返回消息所属模块，可以为 nil；state 为已有翻译的状态
+func NewXLIFFDocument(sourceLang, targetLang string, source, translations []*i18n.Message, moduleOf func(id string) string, state XLIFFState) (*XLIFFDocument, error) {
+	tag, err := language.Parse(targetLang)
+	if err != nil {
+		return nil, fmt.Errorf("invalid language code %q: %w", targetLang, err)
+	}
+	if state == "" {
+		state = XLIFFStateTranslated
+	}
+
+	translationByID := indexMessages(translations)
+	doc := &XLIFFDocument{SourceLang: sourceLang, TargetLang: targetLang}
+
+	modules := make(map[string]string, len(source))
+	for _, src := range source {
+		if moduleOf != nil {
+			modules[src.ID] = moduleOf(src.ID)
+		}
+	}
+
+	// 按模块和消息ID排序，与 <file> 的分组顺序一致
+	sorted := append([]*i18n.Message(nil), source...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if mi, mj := modules[sorted[i].ID], modules[sorted[j].ID]; mi != mj {
+			return mi < mj
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	for _, src := range sorted {
+		module := modules[src.ID]
+		translation := translationByID[src.ID]
+
+		note := src.Description
+		if translation != nil && translation.Description != "" {
+			note = translation.Description
+		}
+
+		if !IsPluralMessage(src) && !IsPluralMessage(translation) {
+			doc.Units = append(doc.Units, newXLIFFUnit(module, src.ID, "", src.Other, translation, plural.Other, note, state))
+			continue
+		}
+
+		for _, form := range targetPluralForms(tag) {
+			doc.Units = append(doc.Units, newXLIFFUnit(module, src.ID, pluralFormName(form), pluralForm(src, form), translation, form, note, state))
+		}
+	}
+
+	return doc, nil
+}
+
+// newXLIFFUnit 创建翻译单元，未翻译时状态为 new
+func newXLIFFUnit(module, id, formName, source string, translation *i18n.Message, form plural.Form, note string, state XLIFFState) XLIFFUnit {
+	unit := XLIFFUnit{
+		Module: module,
+		ID:     id,
+		Form:   formName,
+		Source: source,
+		Note:   note,
+		State:  XLIFFStateNew,
+	}
+	if translation != nil {
+		if target := pluralForm(translation, form); target != "" {
+			unit.Target = target
+			unit.State = state
+		}
+	}
+	return unit
+}
+
+// targetPluralForms 目标语言需要翻译的复数形式（总是包含 other）
+func targetPluralForms(tag language.Tag) []plural.Form {
+	forms := cldrIntegerForms(tag)
+	for _, form := range forms {
+		if form == plural.Other {
+			return forms
+		}
+	}
+	return append(forms, plural.Other)
+}
+
+// pluralFormNames 复数形式名称
+var pluralFormNames = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: "other",
+}
+
+// pluralFormName 获取复数形式名称
+func pluralFormName(form plural.Form) string {
+	return pluralFormNames[form]
+}
+
+// parsePluralFormName 解析复数形式名称
+func parsePluralFormName(name string) (plural.Form, bool) {
+	for form, formName := range pluralFormNames {
+		if formName == name {
+			return form, true
+		}
+	}
+	return 0, false
+}
+
+// Messages 将翻译单元转换为消息，按模块分组
+// 低于 minState 或没有译文的单元会被忽略
+func (d *XLIFFDocument) Messages(minState XLIFFState) map[string][]*i18n.Message {
+	byModule := make(map[string][]*i18n.Message)
+	index := make(map[string]*i18n.Message)
+
+	for _, unit := range d.Units {
+		if unit.Target == "" || !unit.State.AtLeast(minState) {
+			continue
+		}
+
+		key := unit.Module + "\x00" + unit.ID
+		m, exists := index[key]
+		if !exists {
+			m = &i18n.Message{ID: unit.ID, Description: unit.Note}
+			index[key] = m
+			byModule[unit.Module] = append(byModule[unit.Module], m)
+		}
+
+		form := plural.Other
+		if unit.Form != "" {
+			form, _ = parsePluralFormName(unit.Form)
+		}
+		setPluralForm(m, form, unit.Target)
+	}
+
+	// go-i18n 要求 other 形式存在
+	for _, messages := range byModule {
+		for _, m := range messages {
+			if m.Other == "" {
+				m.Other = firstNonEmpty(m.Many, m.Few, m.Two, m.One, m.Zero)
+			}
+		}
+	}
+	return byModule
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// xliff12 XLIFF 1.2 文档结构
+type xliff12 struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Version string        `xml:"version,attr"`
+	Files   []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Body           xliff12Body `xml:"body"`
+}
+
+// xliff12Body 按顺序排列的 *xliff12Unit 和 *xliff12Group
+type xliff12Body struct {
+	Elements []interface{} `xml:",any"`
+}
+
+// UnmarshalXML 实现 xml.Unmarshaler，保持单元和复数消息组的顺序
+func (b *xliff12Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var err error
+	b.Elements, err = decodeXLIFFElements(d, func(name string) interface{} {
+		switch name {
+		case "trans-unit":
+			return &xliff12Unit{}
+		case "group":
+			return &xliff12Group{}
+		}
+		return nil
+	})
+	return err
+}
+
+// xliff12Unit 复数形式的单元 resname 为形式名称
+type xliff12Unit struct {
+	XMLName xml.Name       `xml:"trans-unit"`
+	ID      string         `xml:"id,attr"`
+	Resname string         `xml:"resname,attr,omitempty"`
+	Source  string         `xml:"source"`
+	Target  *xliff12Target `xml:"target"`
+	Notes   []string       `xml:"note"`
+}
+
+type xliff12Target struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// xliff12Group 复数消息，id 为消息ID
+type xliff12Group struct {
+	XMLName xml.Name      `xml:"group"`
+	ID      string        `xml:"id,attr"`
+	Restype string        `xml:"restype,attr,omitempty"`
+	Units   []xliff12Unit `xml:"trans-unit"`
+}
+
+// xliff12PluralRestype 复数消息 <group> 的 restype
+const xliff12PluralRestype = "x-gettext-plurals"
+
+// xliff20 XLIFF 2.0 文档结构
+type xliff20 struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Version string        `xml:"version,attr"`
+	SrcLang string        `xml:"srcLang,attr"`
+	TrgLang string        `xml:"trgLang,attr,omitempty"`
+	Files   []xliff20File `xml:"file"`
+}
+
+// xliff20File Elements 为按顺序排列的 *xliff20Unit 和 *xliff20Group
+type xliff20File struct {
+	ID       string        `xml:"id,attr"`
+	Elements []interface{} `xml:",any"`
+}
+
+// UnmarshalXML 实现 xml.Unmarshaler，保持单元和复数消息组的顺序
+func (f *xliff20File) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "id" {
+			f.ID = attr.Value
+		}
+	}
+	var err error
+	f.Elements, err = decodeXLIFFElements(d, func(name string) interface{} {
+		switch name {
+		case "unit":
+			return &xliff20Unit{}
+		case "group":
+			return &xliff20Group{}
+		}
+		return nil
+	})
+	return err
+}
+
+// xliff20Unit 2.0 的 id 必须是 NMTOKEN，消息ID（复数消息中为形式名称）写入 name 属性
+type xliff20Unit struct {
+	XMLName  xml.Name         `xml:"unit"`
+	ID       string           `xml:"id,attr"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Notes    *xliff20Notes    `xml:"notes"`
+	Segments []xliff20Segment `xml:"segment"`
+}
+
+type xliff20Notes struct {
+	Notes []string `xml:"note"`
+}
+
+type xliff20Segment struct {
+	State  string  `xml:"state,attr,omitempty"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+// xliff20Group 复数消息，name 为消息ID
+type xliff20Group struct {
+	XMLName xml.Name      `xml:"group"`
+	ID      string        `xml:"id,attr"`
+	Name    string        `xml:"name,attr"`
+	Units   []xliff20Unit `xml:"unit"`
+}
+
+// decodeXLIFFElements 按顺序解码当前元素的子元素直到结束标签，newElement 返回 nil 的元素会被跳过
+func decodeXLIFFElements(d *xml.Decoder, newElement func(name string) interface{}) ([]interface{}, error) {
+	var elements []interface{}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			v := newElement(t.Name.Local)
+			if v == nil {
+				if err := d.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := d.DecodeElement(v, &t); err != nil {
+				return nil, err
+			}
+			elements = append(elements, v)
+		case xml.EndElement:
+			return elements, nil
+		}
+	}
+}
+
+// EncodeXLIFF 将文档编码为指定版本的 XLIFF
+func EncodeXLIFF(doc *XLIFFDocument, version string) ([]byte, error) {
+	var v interface{}
+	switch version {
+	case XLIFFVersion12, "":
+		v = doc.xliff12()
+	case XLIFFVersion20:
+		v = doc.xliff20()
+	default:
+		return nil, fmt.Errorf("unsupported xliff version: %s", version)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// groupUnitsByModule 按模块分组翻译单元，保持出现顺序
+func (d *XLIFFDocument) groupUnitsByModule() ([]string, map[string][]XLIFFUnit) {
+	var modules []string
+	byModule := make(map[string][]XLIFFUnit)
+	for _, unit := range d.Units {
+		module := unit.Module
+		if module == "" {
+			module = flatModuleName
+		}
+		if _, exists := byModule[module]; !exists {
+			modules = append(modules, module)
+		}
+		byModule[module] = append(byModule[module], unit)
+	}
+	sort.Strings(modules)
+	return modules, byModule
+}
+
+// pluralRuns 将模块中的单元分为连续的段：非复数消息的单元各自一段，同一复数消息的所有形式为一段
+func pluralRuns(units []XLIFFUnit) [][]XLIFFUnit {
+	var runs [][]XLIFFUnit
+	for i := 0; i < len(units); {
+		j := i + 1
+		if units[i].Form != "" {
+			for j < len(units) && units[j].Form != "" && units[j].ID == units[i].ID {
+				j++
+			}
+		}
+		runs = append(runs, units[i:j])
+		i = j
+	}
+	return runs
+}
+
+func (d *XLIFFDocument) xliff12() *xliff12 {
+	doc := &xliff12{Xmlns: xliff12Namespace, Version: XLIFFVersion12}
+	modules, byModule := d.groupUnitsByModule()
+	for _, module := range modules {
+		file := xliff12File{
+			Original:       module,
+			SourceLanguage: d.SourceLang,
+			TargetLanguage: d.TargetLang,
+			Datatype:       "plaintext",
+		}
+		for _, run := range pluralRuns(byModule[module]) {
+			if run[0].Form == "" {
+				u := newXLIFF12Unit(run[0], run[0].ID)
+				file.Body.Elements = append(file.Body.Elements, &u)
+				continue
+			}
+
+			group := &xliff12Group{ID: run[0].ID, Restype: xliff12PluralRestype}
+			for _, unit := range run {
+				u := newXLIFF12Unit(unit, unit.ID+"["+unit.Form+"]")
+				u.Resname = unit.Form
+				group.Units = append(group.Units, u)
+			}
+			file.Body.Elements = append(file.Body.Elements, group)
+		}
+		doc.Files = append(doc.Files, file)
+	}
+	return doc
+}
+
+// newXLIFF12Unit 创建 1.2 的 <trans-unit>
+func newXLIFF12Unit(unit XLIFFUnit, id string) xliff12Unit {
+	u := xliff12Unit{
+		ID:     id,
+		Source: unit.Source,
+		Target: &xliff12Target{State: string(unit.State), Text: unit.Target},
+	}
+	if unit.Note != "" {
+		u.Notes = []string{unit.Note}
+	}
+	return u
+}
+
+func (d *XLIFFDocument) xliff20() *xliff20 {
+	doc := &xliff20{Xmlns: xliff20Namespace, Version: XLIFFVersion20, SrcLang: d.SourceLang, TrgLang: d.TargetLang}
+	modules, byModule := d.groupUnitsByModule()
+	for _, module := range modules {
+		file := xliff20File{ID: module}
+		var units, groups int
+		newUnit := func(unit XLIFFUnit, name string) xliff20Unit {
+			units++
+			state := string(unit.State)
+			if unit.State == XLIFFStateNew {
+				state = "initial"
+			}
+			target := unit.Target
+			u := xliff20Unit{
+				ID:   "u" + strconv.Itoa(units),
+				Name: name,
+				Segments: []xliff20Segment{{
+					State:  state,
+					Source: unit.Source,
+					Target: &target,
+				}},
+			}
+			if unit.Note != "" {
+				u.Notes = &xliff20Notes{Notes: []string{unit.Note}}
+			}
+			return u
+		}
+
+		for _, run := range pluralRuns(byModule[module]) {
+			if run[0].Form == "" {
+				u := newUnit(run[0], run[0].ID)
+				file.Elements = append(file.Elements, &u)
+				continue
+			}
+
+			groups++
+			group := &xliff20Group{ID: "g" + strconv.Itoa(groups), Name: run[0].ID}
+			for _, unit := range run {
+				group.Units = append(group.Units, newUnit(unit, unit.Form))
+			}
+			file.Elements = append(file.Elements, group)
+		}
+		doc.Files = append(doc.Files, file)
+	}
+	return doc
+}
+
+// ParseXLIFF 解析 XLIFF 1.2 或 2.0 文件
+func ParseXLIFF(buf []byte) (*XLIFFDocument, error) {
+	var probe struct {
+		XMLName xml.Name
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(buf, &probe); err != nil {
+		return nil, fmt.Errorf("invalid xliff: %w", err)
+	}
+	if probe.XMLName.Local != "xliff" {
+		return nil, fmt.Errorf("invalid xliff: unexpected root element <%s>", probe.XMLName.Local)
+	}
+
+	switch {
+	case strings.HasPrefix(probe.Version, "1."):
+		return parseXLIFF12(buf)
+	case strings.HasPrefix(probe.Version, "2."):
+		return parseXLIFF20(buf)
+	}
+	return nil, fmt.Errorf("unsupported xliff version: %s", probe.Version)
+}
+
+func parseXLIFF12(buf []byte) (*XLIFFDocument, error) {
+	var x xliff12
+	if err := xml.Unmarshal(buf, &x); err != nil {
+		return nil, fmt.Errorf("invalid xliff: %w", err)
+	}
+
+	doc := &XLIFFDocument{}
+	for _, file := range x.Files {
+		if doc.SourceLang == "" {
+			doc.SourceLang, doc.TargetLang = file.SourceLanguage, file.TargetLanguage
+		}
+		for _, element := range file.Body.Elements {
+			switch e := element.(type) {
+			case *xliff12Unit:
+				unit, err := newXLIFF12ParsedUnit(file.Original, e.ID, "", e)
+				if err != nil {
+					return nil, err
+				}
+				doc.Units = append(doc.Units, unit)
+			case *xliff12Group:
+				for i := range e.Units {
+					unit, err := newXLIFF12ParsedUnit(file.Original, e.ID, e.Units[i].Resname, &e.Units[i])
+					if err != nil {
+						return nil, err
+					}
+					doc.Units = append(doc.Units, unit)
+				}
+			}
+		}
+	}
+	return doc, nil
+}
+
+// newXLIFF12ParsedUnit 根据 1.2 的 <trans-unit> 创建翻译单元
+func newXLIFF12ParsedUnit(module, id, form string, u *xliff12Unit) (XLIFFUnit, error) {
+	unit, err := newParsedUnit(module, id, form, u.Source, u.Notes)
+	if err != nil {
+		return unit, fmt.Errorf("trans-unit %s: %w", u.ID, err)
+	}
+	if u.Target != nil {
+		unit.Target = u.Target.Text
+		if err := unit.setState(u.Target.State); err != nil {
+			return unit, fmt.Errorf("trans-unit %s: %w", u.ID, err)
+		}
+	}
+	return unit, nil
+}
+
+func parseXLIFF20(buf []byte) (*XLIFFDocument, error) {
+	var x xliff20
+	if err := xml.Unmarshal(buf, &x); err != nil {
+		return nil, fmt.Errorf("invalid xliff: %w", err)
+	}
+
+	doc := &XLIFFDocument{SourceLang: x.SrcLang, TargetLang: x.TrgLang}
+	for _, file := range x.Files {
+		for _, element := range file.Elements {
+			switch e := element.(type) {
+			case *xliff20Unit:
+				name := e.Name
+				if name == "" {
+					name = e.ID
+				}
+				unit, err := newXLIFF20ParsedUnit(file.ID, name, "", e)
+				if err != nil {
+					return nil, err
+				}
+				doc.Units = append(doc.Units, unit)
+			case *xliff20Group:
+				for i := range e.Units {
+					unit, err := newXLIFF20ParsedUnit(file.ID, e.Name, e.Units[i].Name, &e.Units[i])
+					if err != nil {
+						return nil, err
+					}
+					doc.Units = append(doc.Units, unit)
+				}
+			}
+		}
+	}
+	return doc, nil
+}
+
+// newXLIFF20ParsedUnit 根据 2.0 的 <unit> 创建翻译单元，多个 segment 拼接为一条文本，状态取第一个 segment
+func newXLIFF20ParsedUnit(module, id, form string, u *xliff20Unit) (XLIFFUnit, error) {
+	var notes []string
+	if u.Notes != nil {
+		notes = u.Notes.Notes
+	}
+	unit, err := newParsedUnit(module, id, form, "", notes)
+	if err != nil {
+		return unit, fmt.Errorf("unit %s: %w", u.ID, err)
+	}
+
+	var target strings.Builder
+	hasTarget := false
+	for i, segment := range u.Segments {
+		unit.Source += segment.Source
+		if segment.Target != nil {
+			target.WriteString(*segment.Target)
+			hasTarget = true
+		}
+		if i == 0 {
+			if err := unit.setState(segment.State); err != nil {
+				return unit, fmt.Errorf("unit %s: %w", u.ID, err)
+			}
+		}
+	}
+	if hasTarget {
+		unit.Target = target.String()
+	}
+	return unit, nil
+}
+
+// newParsedUnit 根据 XLIFF 中的文件名、消息ID和复数形式创建翻译单元，复数消息组中的形式必须是 CLDR 复数形式
+func newParsedUnit(module, id, form, source string, notes []string) (XLIFFUnit, error) {
+	if module == flatModuleName {
+		module = ""
+	}
+
+	unit := XLIFFUnit{
+		Module: module,
+		ID:     id,
+		Form:   form,
+		Source: source,
+		Note:   strings.Join(notes, "\n"),
+		State:  XLIFFStateNew,
+	}
+	if id == "" {
+		return unit, fmt.Errorf("missing message id")
+	}
+	if form != "" {
+		if _, ok := parsePluralFormName(form); !ok {
+			return unit, fmt.Errorf("message %s: invalid plural form %q", id, form)
+		}
+	}
+	return unit, nil
+}
+
+// setState 设置单元状态，有译文但未声明状态时视为已翻译
+func (u *XLIFFUnit) setState(state string) error {
+	if state == "" {
+		if u.Target != "" {
+			u.State = XLIFFStateTranslated
+		}
+		return nil
+	}
+
+	parsed, err := ParseXLIFFState(state)
+	if err != nil {
+		return err
+	}
+	u.State = parsed
+	return nil
+}
@@ -0,0 +1,209 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestXLIFFRoundTrip(t *testing.T) {
+	source := []*i18n.Message{
+		{ID: "WELCOME", Description: "Greeting", Other: "Welcome"},
+		{ID: "NOT_FOUND", Other: "Not found"},
+		{ID: "FILES", One: "{{.Count}} file", Other: "{{.Count}} files"},
+	}
+	translations := []*i18n.Message{
+		{ID: "WELCOME", Other: "Bienvenue"},
+		{ID: "FILES", One: "{{.Count}} fichier", Other: "{{.Count}} fichiers"},
+	}
+	moduleOf := func(id string) string {
+		if id == "NOT_FOUND" {
+			return "errors"
+		}
+		return "common"
+	}
+
+	for _, version := range []string{XLIFFVersion12, XLIFFVersion20} {
+		t.Run(version, func(t *testing.T) {
+			doc, err := NewXLIFFDocument("en", "fr", source, translations, moduleOf, XLIFFStateFinal)
+			require.NoError(t, err)
+
+			data, err := EncodeXLIFF(doc, version)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "Greeting")
+			assert.Contains(t, string(data), "Not found")
+
+			parsed, err := ParseXLIFF(data)
+			require.NoError(t, err)
+			assert.Equal(t, "en", parsed.SourceLang)
+			assert.Equal(t, "fr", parsed.TargetLang)
+			assert.Equal(t, doc.Units, parsed.Units)
+
+			byModule := parsed.Messages(XLIFFStateTranslated)
+			require.Len(t, byModule["common"], 2)
+			assert.Empty(t, byModule["errors"])
+
+			messages := indexMessages(byModule["common"])
+			assert.Equal(t, "Bienvenue", messages["WELCOME"].Other)
+			assert.Equal(t, "Greeting", messages["WELCOME"].Description)
+			assert.Equal(t, "{{.Count}} fichier", messages["FILES"].One)
+			assert.Equal(t, "{{.Count}} fichiers", messages["FILES"].Other)
+		})
+	}
+}
+
+func TestXLIFFVariantNamedLikePluralForm(t *testing.T) {
+	source := []*i18n.Message{
+		{ID: "ITEM#other", Other: "Another item"},
+		{ID: "GIFT#one", One: "{{.Count}} gift", Other: "{{.Count}} gifts"},
+	}
+	translations := []*i18n.Message{
+		{ID: "ITEM#other", Other: "Un autre article"},
+		{ID: "GIFT#one", One: "{{.Count}} cadeau", Other: "{{.Count}} cadeaux"},
+	}
+
+	for _, version := range []string{XLIFFVersion12, XLIFFVersion20} {
+		t.Run(version, func(t *testing.T) {
+			doc, err := NewXLIFFDocument("en", "fr", source, translations, nil, XLIFFStateFinal)
+			require.NoError(t, err)
+			data, err := EncodeXLIFF(doc, version)
+			require.NoError(t, err)
+
+			parsed, err := ParseXLIFF(data)
+			require.NoError(t, err)
+			assert.Equal(t, doc.Units, parsed.Units)
+
+			messages := parsed.Messages(XLIFFStateTranslated)[""]
+			assert.Equal(t, []*i18n.Message{
+				{ID: "GIFT#one", One: "{{.Count}} cadeau", Other: "{{.Count}} cadeaux"},
+				{ID: "ITEM#other", Other: "Un autre article"},
+			}, messages)
+		})
+	}
+
+	_, err := ParseXLIFF([]byte(`<xliff version="1.2"><file original="messages"><body><group id="GIFT"><trans-unit id="GIFT[several]" resname="several"><source>x</source></trans-unit></group></body></file></xliff>`))
+	assert.ErrorContains(t, err, `invalid plural form "several"`)
+}
+
+func TestParseXLIFFStates(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2">
+  <file original="messages" source-language="en" target-language="de" datatype="plaintext">
+    <body>
+      <trans-unit id="SAVE">
+        <source>Save</source>
+        <target state="final">Speichern</target>
+      </trans-unit>
+      <trans-unit id="CANCEL">
+        <source>Cancel</source>
+        <target state="needs-review-translation">Abbrechen</target>
+      </trans-unit>
+      <trans-unit id="DELETE">
+        <source>Delete</source>
+        <target>Löschen</target>
+      </trans-unit>
+      <trans-unit id="EDIT">
+        <source>Edit</source>
+        <target state="new"></target>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>`
+
+	doc, err := ParseXLIFF([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, "de", doc.TargetLang)
+
+	final := doc.Messages(XLIFFStateFinal)
+	require.Len(t, final[""], 1)
+	assert.Equal(t, "Speichern", final[""][0].Other)
+
+	translated := indexMessages(doc.Messages(XLIFFStateTranslated)[""])
+	assert.Len(t, translated, 3)
+	assert.Equal(t, "Löschen", translated["DELETE"].Other)
+
+	_, err = ParseXLIFF([]byte(`<xliff version="3.0"></xliff>`))
+	assert.Error(t, err)
+	_, err = ParseXLIFF([]byte(`<xliff version="1.2"><file><body><trans-unit id="A"><target state="bogus">x</target></trans-unit></body></file></xliff>`))
+	assert.Error(t, err)
+}
+
+func TestLocaleLoaderWriteMessages(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en/common.json": `[{"id": "WELCOME", "translation": "Welcome"}]`,
+		"fr/common.yaml": "WELCOME: Salut\nGOODBYE: Au revoir\n",
+	})
+
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, nil)
+	written, err := loader.WriteMessages("fr", map[string][]*i18n.Message{
+		"common": {{ID: "WELCOME", Other: "Bienvenue"}},
+		"errors": {{ID: "NOT_FOUND", Other: "Introuvable"}},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "fr", "common.yaml"),
+		filepath.Join(dir, "fr", "errors.json"),
+	}, written)
+
+	bundle := i18n.NewBundle(language.English)
+	require.NoError(t, NewLocaleLoader(LocaleLoaderConfig{Path: dir}, bundle).LoadLocales())
+	loc := i18n.NewLocalizer(bundle, "fr")
+	for id, want := range map[string]string{"WELCOME": "Bienvenue", "GOODBYE": "Au revoir", "NOT_FOUND": "Introuvable"} {
+		msg, err := loc.Localize(&i18n.LocalizeConfig{MessageID: id})
+		require.NoError(t, err)
+		assert.Equal(t, want, msg)
+	}
+
+	// 演练模式不写文件
+	written, err = loader.WriteMessages("de", map[string][]*i18n.Message{"ui": {{ID: "SAVE", Other: "Speichern"}}}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "de", "ui.json")}, written)
+	_, err = os.Stat(filepath.Join(dir, "de"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestXLIFFImportKeepsMetadata(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"en.json": `{"home": {"title": {"other": "Welcome, {{.Name}}", "description": "Home title", "max_length": 30, "placeholders": ["Name"], "owner": "growth"}}}`,
+		"fr.json": `{"home": {"title": {"other": "Bienvenue", "max_length": 30, "owner": "fr-team"}, "subtitle": "Ancien"}}`,
+		"de.json": `[{"id": "home.title", "translation": "Willkommen", "owner": "de-team"}]`,
+	})
+
+	source := []*i18n.Message{{ID: "home.title", Description: "Home title", Other: "Welcome, {{.Name}}"}}
+	loader := NewLocaleLoader(LocaleLoaderConfig{Path: dir}, nil)
+	for lang, text := range map[string]string{"fr": "Bienvenue, {{.Name}}", "de": "Willkommen, {{.Name}}"} {
+		doc, err := NewXLIFFDocument("en", lang, source, []*i18n.Message{{ID: "home.title", Other: text}}, nil, XLIFFStateFinal)
+		require.NoError(t, err)
+		data, err := EncodeXLIFF(doc, XLIFFVersion12)
+		require.NoError(t, err)
+
+		parsed, err := ParseXLIFF(data)
+		require.NoError(t, err)
+		_, err = loader.WriteMessages(lang, parsed.Messages(XLIFFStateTranslated), false)
+		require.NoError(t, err)
+	}
+
+	// 树形文件和数组文件都保留元数据
+	data, err := os.ReadFile(filepath.Join(dir, "fr.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"home": {"title": {"other": "Bienvenue, {{.Name}}", "description": "Home title", "max_length": 30, "owner": "fr-team"}, "subtitle": "Ancien"}}`, string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "de.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "home.title", "description": "Home title", "translation": "Willkommen, {{.Name}}", "owner": "de-team"}]`, string(data))
+
+	// 重新加载后元数据不变
+	loader = NewLocaleLoader(LocaleLoaderConfig{Path: dir}, i18n.NewBundle(language.English))
+	require.NoError(t, loader.LoadLocales())
+	info, ok := loader.MessageInfo("en", "home.title")
+	require.True(t, ok)
+	assert.Equal(t, 30, info.MaxLength)
+	assert.Equal(t, []string{"Name"}, info.Placeholders)
+	assert.Equal(t, "growth", info.Owner)
+	assert.Equal(t, []string{"de", "en", "fr"}, info.Languages)
+}
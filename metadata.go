@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// MessageInfo 消息的元数据：描述、最大长度、占位符、负责人、所属模块和包含该消息的语言
+// 元数据写在语言文件的消息对象中，优先取默认语言中的值：
+//
+//	{"WELCOME": {"other": "Welcome, {{.Name}}", "description": "首页标题",
+//	             "max_length": 30, "placeholders": ["Name"], "owner": "growth"}}
+type MessageInfo = internal.MessageInfo
+
+// MessageIssue 消息校验发现的问题
+type MessageIssue = internal.MessageIssue
+
+// IssueKind 消息校验问题的类型
+type IssueKind = internal.IssueKind
+
+const (
+	IssueMaxLength   = internal.IssueMaxLength   // 超过 max_length
+	IssuePlaceholder = internal.IssuePlaceholder // 使用了默认语言未声明的占位符
+
+	IssueTemplate            = internal.IssueTemplate            // 模板无法解析
+	IssuePlaceholderMismatch = internal.IssuePlaceholderMismatch // 占位符集合与默认语言不同
+
+	IssueDeclaredPlaceholders = internal.IssueDeclaredPlaceholders // 翻译中声明了占位符（只有默认语言的声明生效）
+)
+
+// TemplateCheckError 加载时模板检查发现的问题，Config.TemplateCheck 为 "error" 时由 NewService 和 Reload 返回
+type TemplateCheckError = internal.TemplateCheckError
+
+// MessageInfos 返回所有已加载消息和运行时覆盖的消息的元数据，按消息ID排序
+func (s *Service) MessageInfos() []MessageInfo {
+	infos := s.localeLoader().MessageInfos(s.sourceLanguage())
+	index := make(map[string]int, len(infos))
+	for i, info := range infos {
+		index[info.ID] = i
+	}
+	for _, o := range s.overrides.List() {
+		i, ok := index[o.ID]
+		if !ok {
+			i = len(infos)
+			index[o.ID] = i
+			infos = append(infos, MessageInfo{ID: o.ID, Languages: []string{}})
+		}
+		infos[i].Overrides = append(infos[i].Overrides, o.Lang)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// MessageInfo 返回消息的元数据，Languages 为语言文件中包含该消息的语言，Overrides 为运行时覆盖了该消息的语言
+// 消息既不在语言文件中也没有覆盖时返回 false
+func (s *Service) MessageInfo(id string) (MessageInfo, bool) {
+	info, ok := s.localeLoader().MessageInfo(s.sourceLanguage(), id)
+	info.Overrides = s.overrides.Languages(id)
+	return info, ok || len(info.Overrides) > 0
+}
+
+// ValidateMessages 按元数据校验已加载的消息：超过 max_length 的翻译，使用了未声明占位符的翻译，
+// 以及自行声明了 placeholders 的翻译；消息没有声明 placeholders 时，默认语言消息中使用的占位符视为已声明
+func (s *Service) ValidateMessages() []MessageIssue {
+	return s.localeLoader().ValidateMessages(s.sourceLanguage())
+}
+
+// ValidateMessages 按元数据校验已加载的消息
+func ValidateMessages() []MessageIssue {
+	return GetService().ValidateMessages()
+}
+
+// CheckTemplates 编译已加载的所有语言中的消息模板，报告无法解析的模板（包括使用了未知的模板函数），
+// 以及占位符集合与默认语言中同一消息不同的翻译
+func (s *Service) CheckTemplates() []MessageIssue {
+	return checkLoaderTemplates(s.localeLoader(), s.sourceLanguage())
+}
+
+// checkLoaderTemplates 检查加载器中已加载的消息模板
+func checkLoaderTemplates(loader *internal.LocaleLoader, source string) []MessageIssue {
+	return loader.CheckTemplates(source, templateFuncs(source, nil, nil))
+}
+
+// CheckTemplates 检查已加载的消息模板
+func CheckTemplates() []MessageIssue {
+	return GetService().CheckTemplates()
+}
+
+// checkTemplates 按 Config.TemplateCheck 在加载语言文件后检查 loader 中的消息模板
+func (s *Service) checkTemplates(loader *internal.LocaleLoader) error {
+	mode := s.templateCheckMode()
+	if mode == internal.TemplateCheckOff {
+		return nil
+	}
+
+	issues := checkLoaderTemplates(loader, s.sourceLanguage())
+	if len(issues) == 0 {
+		return nil
+	}
+	if mode == internal.TemplateCheckFail {
+		return &TemplateCheckError{Issues: issues}
+	}
+	for _, issue := range issues {
+		log.Printf("[i18n] Template check: %v", issue)
+	}
+	return nil
+}
+
+// templateCheckMode 加载时模板检查的处理方式，严格模式下未设置 TemplateCheck 时为 "error"
+func (s *Service) templateCheckMode() internal.TemplateCheckMode {
+	if s.config.Strict && strings.TrimSpace(s.config.TemplateCheck) == "" {
+		return internal.TemplateCheckFail
+	}
+	mode, _ := internal.ParseTemplateCheckMode(s.config.TemplateCheck)
+	return mode
+}
+
+// sourceLanguage 元数据和校验的源语言，即默认语言（未配置时为降级语言）
+func (s *Service) sourceLanguage() string {
+	if s.config.DefaultLanguage != "" {
+		return s.config.DefaultLanguage
+	}
+	return s.config.FallbackLanguage
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/text/language"
+
+	"github.com/chenguowei/go-i18n/internal"
 )
 
 // MiddlewareOptions 中间件选项
@@ -55,6 +57,7 @@ func MiddlewareWithOpts(opts MiddlewareOptions) gin.HandlerFunc {
 		c.Set("i18n_language", lang)
 		c.Set("i18n_language_source", getLanguageSource(c, opts))
 		c.Set("i18n_language_quality", getLanguageQuality(c, lang))
+		c.Set(string(DirectionKey), GetService().translator.Direction(lang))
 
 		// 设置响应头
 		c.Header("Content-Language", lang)
@@ -83,16 +86,16 @@ func MiddlewareWithOpts(opts MiddlewareOptions) gin.HandlerFunc {
 func detectLanguage(c *gin.Context, opts MiddlewareOptions, matcher language.Matcher) string {
 	// 1. Header 优先级最高
 	if header := c.GetHeader(opts.HeaderKey); header != "" {
-		if isValidLanguage(header, opts.SupportedLangs) {
-			return normalizeLanguage(header)
+		if lang := resolveRequestLanguage(header, opts.SupportedLangs); lang != "" {
+			return lang
 		}
 	}
 
 	// 2. Cookie
 	if opts.EnableCookie {
 		if cookie, err := c.Cookie(opts.CookieName); err == nil {
-			if isValidLanguage(cookie, opts.SupportedLangs) {
-				return normalizeLanguage(cookie)
+			if lang := resolveRequestLanguage(cookie, opts.SupportedLangs); lang != "" {
+				return lang
 			}
 		}
 	}
@@ -100,15 +103,15 @@ func detectLanguage(c *gin.Context, opts MiddlewareOptions, matcher language.Mat
 	// 3. Query Parameter
 	if opts.EnableQuery {
 		if query := c.Query(opts.QueryKey); query != "" {
-			if isValidLanguage(query, opts.SupportedLangs) {
-				return normalizeLanguage(query)
+			if lang := resolveRequestLanguage(query, opts.SupportedLangs); lang != "" {
+				return lang
 			}
 		}
 	}
 
 	// 4. Accept-Language Header
 	if accept := c.GetHeader("Accept-Language"); accept != "" {
-		if lang := parseAcceptLanguage(accept, matcher); lang != "" {
+		if lang := parseAcceptLanguage(accept, matcher, opts.SupportedLangs); lang != "" {
 			return lang
 		}
 	}
@@ -117,15 +120,56 @@ func detectLanguage(c *gin.Context, opts MiddlewareOptions, matcher language.Mat
 	return GetService().config.DefaultLanguage
 }
 
+// resolveRequestLanguage 解析 Header、Cookie 或 Query 中指定的语言
+// 语言不受支持时按降级链查找受支持的语言（如 zh-HK -> zh-TW），仍然没有时返回空字符串；
+// 启用伪本地化时 en-XA、ar-XB 总是有效
+func resolveRequestLanguage(lang string, supportedLangs []string) string {
+	if isValidLanguage(lang, supportedLangs) {
+		return normalizeLanguage(lang)
+	}
+	if _, ok := internal.ParsePseudoLocale(lang); ok && GetService().config.PseudoLocalization {
+		return normalizeLanguage(lang)
+	}
+	if supported := supportedFallback(lang, supportedLangs); supported != "" {
+		return normalizeLanguage(supported)
+	}
+	return ""
+}
+
+// supportedFallback 返回语言降级链中第一个受支持的语言（不含全局降级语言）
+func supportedFallback(lang string, supportedLangs []string) string {
+	for _, parent := range GetService().fallbacks.Parents(lang) {
+		for _, supported := range supportedLangs {
+			if normalizeLanguage(supported) == normalizeLanguage(parent) {
+				return supported
+			}
+		}
+	}
+	return ""
+}
+
 // parseAcceptLanguage 解析 Accept-Language Header
-func parseAcceptLanguage(accept string, matcher language.Matcher) string {
+// 按权重依次检查每个语言及其降级链，都不受支持时使用语言匹配器
+func parseAcceptLanguage(accept string, matcher language.Matcher, supportedLangs []string) string {
 	tags, _, err := language.ParseAcceptLanguage(accept)
 	if err != nil || len(tags) == 0 {
 		return ""
 	}
 
-	if tag, _, conf := matcher.Match(tags...); conf > language.No {
-		return tag.String()
+	for _, tag := range tags {
+		lang := tag.String()
+		for _, supported := range supportedLangs {
+			if normalizeLanguage(supported) == normalizeLanguage(lang) {
+				return supported
+			}
+		}
+		if supported := supportedFallback(lang, supportedLangs); supported != "" {
+			return supported
+		}
+	}
+
+	if _, index, conf := matcher.Match(tags...); conf > language.No && index < len(supportedLangs) {
+		return supportedLangs[index]
 	}
 
 	return ""
@@ -232,6 +276,10 @@ const (
 	LanguageKey     LanguageContextKey = "i18n_language"
 	LanguageSource  LanguageContextKey = "i18n_language_source"
 	LanguageQuality LanguageContextKey = "i18n_language_quality"
+	NamespaceKey    LanguageContextKey = "i18n_namespace"
+	TimeZoneKey     LanguageContextKey = "i18n_timezone"
+	VariantKey      LanguageContextKey = "i18n_variant"
+	DirectionKey    LanguageContextKey = "i18n_direction"
 )
 
 // GetLanguageFromContext 从上下文获取语言
@@ -245,4 +293,58 @@ func GetLanguageFromContext(ctx context.Context) string {
 // SetLanguageToContext 设置语言到上下文
 func SetLanguageToContext(ctx context.Context, language string) context.Context {
 	return context.WithValue(ctx, LanguageKey, language)
-}
\ No newline at end of file
+}
+
+// GetNamespaceFromContext 从上下文获取默认命名空间
+func GetNamespaceFromContext(ctx context.Context) string {
+	if namespace, ok := ctx.Value(NamespaceKey).(string); ok {
+		return namespace
+	}
+	return ""
+}
+
+// SetNamespaceToContext 设置默认命名空间到上下文，未指定命名空间的消息ID优先在该命名空间中查找
+func SetNamespaceToContext(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, NamespaceKey, namespace)
+}
+
+// SetNamespaceToGin 设置 Gin 请求的默认命名空间
+func SetNamespaceToGin(c *gin.Context, namespace string) {
+	c.Set(string(NamespaceKey), namespace)
+}
+
+// GetTimeZoneFromContext 从上下文获取时区，未设置时返回 nil（日期按原时区格式化）
+func GetTimeZoneFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(TimeZoneKey).(*time.Location); ok {
+		return loc
+	}
+	return nil
+}
+
+// SetTimeZoneToContext 设置时区到上下文，格式化日期和时间前会先转换到该时区
+func SetTimeZoneToContext(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, TimeZoneKey, loc)
+}
+
+// SetTimeZoneToGin 设置 Gin 请求的时区
+func SetTimeZoneToGin(c *gin.Context, loc *time.Location) {
+	c.Set(string(TimeZoneKey), loc)
+}
+
+// GetVariantsFromContext 从上下文获取消息变体（如 "female"、"formal"）
+func GetVariantsFromContext(ctx context.Context) []string {
+	if variants, ok := ctx.Value(VariantKey).([]string); ok {
+		return variants
+	}
+	return nil
+}
+
+// SetVariantsToContext 设置消息变体到上下文，翻译时按顺序查找 ID#变体，都不存在时使用不带变体的消息
+func SetVariantsToContext(ctx context.Context, variants ...string) context.Context {
+	return context.WithValue(ctx, VariantKey, variants)
+}
+
+// SetVariantsToGin 设置 Gin 请求的消息变体
+func SetVariantsToGin(c *gin.Context, variants ...string) {
+	c.Set(string(VariantKey), variants)
+}
@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// MissingTranslation 缺失的翻译
+type MissingTranslation struct {
+	Lang      string // 请求的语言
+	MessageID string // 消息ID（已解析命名空间）
+	Caller    string // 调用翻译函数的位置，如 /app/handlers/user.go:42
+	Fallback  string // 实际使用的降级语言，为空表示所有语言都没有该消息
+}
+
+// MissingHandler 缺失翻译的处理函数，在翻译的调用方 goroutine 中同步调用，需要并发安全且尽量快
+// 报告了缺失的翻译结果（包括使用降级语言的结果）不会被缓存，因此每次翻译都会报告，记录中的次数和调用位置是完整的
+type MissingHandler func(ctx context.Context, missing MissingTranslation)
+
+// MissingRecord 按语言和消息ID去重的缺失翻译记录
+type MissingRecord = internal.MissingRecord
+
+// packagePath 本包的导入路径
+var packagePath = reflect.TypeOf(translator{}).PkgPath()
+
+// callerLocation 返回调用栈中第一个不属于本包（含 internal 包，不含测试文件）的位置
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		inPackage := strings.HasPrefix(frame.Function, packagePath+".") || strings.HasPrefix(frame.Function, packagePath+"/internal.")
+		if !inPackage || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
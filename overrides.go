@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"fmt"
+	texttemplate "text/template"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// Override 运行时覆盖的消息
+type Override = internal.Override
+
+// SetOverride 在运行时覆盖一种语言中的消息，无需修改语言文件或重新部署，覆盖文本的语法与语言文件相同
+// 覆盖优先于语言文件中的消息（包括变体），重新加载语言文件不会清除覆盖；复数翻译的所有形式都使用覆盖文本
+// 配置了 OverridesFile 时覆盖会写入该文件，服务重启后恢复
+func (s *Service) SetOverride(lang, id, text string) error {
+	if err := s.validateOverride(id, text); err != nil {
+		return err
+	}
+	if err := s.overrides.Set(lang, id, text); err != nil {
+		return err
+	}
+	s.invalidateMessage(id)
+	return nil
+}
+
+// RemoveOverride 删除消息覆盖，恢复使用语言文件中的消息，覆盖不存在时不做任何操作
+func (s *Service) RemoveOverride(lang, id string) error {
+	removed, err := s.overrides.Remove(lang, id)
+	if err != nil {
+		return err
+	}
+	if removed {
+		s.invalidateMessage(id)
+	}
+	return nil
+}
+
+// Overrides 返回所有消息覆盖，按消息ID、语言排序
+func (s *Service) Overrides() []Override {
+	return s.overrides.List()
+}
+
+// validateOverride 检查覆盖文本能否按 LocaleConfig.MessageFormat 解析
+func (s *Service) validateOverride(id, text string) error {
+	var err error
+	if internal.MessageFormat(s.config.LocaleConfig.MessageFormat) == internal.ICUFormat {
+		_, err = internal.ParseICU(text)
+	} else {
+		_, err = texttemplate.New(id).Funcs(templateFuncs(s.sourceLanguage(), nil, nil)).Parse(text)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid override for %s: %w", id, err)
+	}
+	return nil
+}
+
+// invalidateMessage 删除消息在所有语言中的翻译缓存（降级语言的覆盖也会影响其他语言）
+func (s *Service) invalidateMessage(id string) {
+	if s.cache != nil {
+		s.cache.DeleteFunc(internal.CacheKeyMatcher(id))
+	}
+}
+
+// SetOverride 在运行时覆盖一种语言中的消息
+func SetOverride(lang, id, text string) error {
+	return GetService().SetOverride(lang, id, text)
+}
+
+// RemoveOverride 删除消息覆盖
+func RemoveOverride(lang, id string) error {
+	return GetService().RemoveOverride(lang, id)
+}
+
+// Overrides 返回所有消息覆盖
+func Overrides() []Override {
+	return GetService().Overrides()
+}
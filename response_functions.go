@@ -38,6 +38,9 @@ func JSONWithStatusAndMeta(c *gin.Context, code Code, data interface{}, httpStat
 	if meta.Language == "" {
 		meta.Language = lang
 	}
+	if meta.Dir == "" {
+		meta.Dir = LanguageDirection(meta.Language)
+	}
 
 	// 设置请求ID
 	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
@@ -120,6 +123,7 @@ func JSONWithTemplateAndStatus(c *gin.Context, code Code, data interface{}, temp
 	if l, exists := c.Get("i18n_language"); exists {
 		if str, ok := l.(string); ok {
 			meta.Language = str
+			meta.Dir = LanguageDirection(str)
 		}
 	}
 
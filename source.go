@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// MessageSource 消息来源：列出语言、加载一种语言的消息、监听变化
+// 通过 Config.MessageSources 组合到服务中，在 LocalesPath 下的语言文件之后加载
+type MessageSource = internal.MessageSource
+
+// FileSource 语言文件目录消息来源
+type FileSource = internal.FileSource
+
+// SQLSource 数据库消息来源
+type SQLSource = internal.SQLSource
+
+// SQLSourceConfig 数据库消息来源配置
+type SQLSourceConfig = internal.SQLSourceConfig
+
+// DefaultSQLTable 数据库消息来源默认的表名
+const DefaultSQLTable = internal.DefaultSQLTable
+
+// SQLSchema 数据库消息来源的表结构，%s 为表名
+const SQLSchema = internal.SQLSchema
+
+// NewFileSource 创建语言文件目录消息来源，目录结构、语言和模块过滤、文件系统等使用 config 中的设置
+func NewFileSource(config Config, localesPath string) *FileSource {
+	return internal.NewFileSource(localeLoaderConfig(config, localesPath))
+}
+
+// NewSQLSource 创建数据库消息来源，从 SQLSchema 结构的表中读取消息，
+// 适合由管理后台编辑翻译，修改后调用 Reload（或设置 PollInterval 并启用 EnableWatcher）生效
+func NewSQLSource(db *sql.DB, config SQLSourceConfig) *SQLSource {
+	return internal.NewSQLSource(db, config)
+}
+
+// watchSources 监听其他消息来源，变化时重新加载
+func (s *Service) watchSources() error {
+	for _, source := range s.config.MessageSources {
+		name := internal.SourceName(source)
+		w, err := source.Watch(func() {
+			if err := s.reloadLocales(); err != nil {
+				log.Printf("[i18n] Failed to reload locales after %s changed: %v", name, err)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %w", name, err)
+		}
+		if w != nil {
+			s.sourceWatchers = append(s.sourceWatchers, w)
+		}
+	}
+	return nil
+}
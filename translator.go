@@ -3,13 +3,16 @@ package i18n
 import (
 	"context"
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"log"
-	"path/filepath"
 	"strings"
+	"sync"
+	texttemplate "text/template"
 	"time"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
 	"golang.org/x/text/language"
 
 	"github.com/chenguowei/go-i18n/internal"
@@ -19,6 +22,12 @@ import (
 type Translator interface {
 	Translate(ctx context.Context, messageID string, templateData ...map[string]interface{}) string
 	TranslateWithLanguage(ctx context.Context, lang, messageID string, templateData ...map[string]interface{}) string
+	Pluralize(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string
+	TranslateTemplate(ctx context.Context, template string, templateData ...map[string]interface{}) string
+	TranslateE(ctx context.Context, messageID string, templateData ...map[string]interface{}) (string, error)
+	TranslateWithLanguageE(ctx context.Context, lang, messageID string, templateData ...map[string]interface{}) (string, error)
+	PluralizeE(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) (string, error)
+	Direction(lang string) Direction
 	Localizer(ctx context.Context) *i18n.Localizer
 	LocalizerWithLanguage(ctx context.Context, lang string) *i18n.Localizer
 	LoadLocales(localesPath string) error
@@ -26,19 +35,68 @@ type Translator interface {
 
 // translator 翻译器实现
 type translator struct {
-	bundle *i18n.Bundle
+	mu     sync.RWMutex
+	state  *localeState // 重新加载时整体替换，读取使用 currentState
 	cache  internal.CacheManager
 	pool   internal.PoolManager
 	config Config
+
+	overrides *internal.OverrideStore // 运行时消息覆盖，可以为空
+
+	fallbacks   *internal.FallbackChains
+	missing     *internal.MissingRegistry
+	icuMessages sync.Map // ICU 消息 -> *internal.ICUMessage
+}
+
+// localeState 翻译器使用的已加载消息，加载后不再修改
+type localeState struct {
+	bundle     *i18n.Bundle
+	loader     *internal.LocaleLoader // 用于解析命名空间，可以为空
+	localizers sync.Map               // 语言 -> *i18n.Localizer（未启用对象池时）
 }
 
 // NewTranslator 创建翻译器
 func NewTranslator(bundle *i18n.Bundle, cache internal.CacheManager, pool internal.PoolManager, config Config) Translator {
+	return newTranslator(bundle, cache, pool, config, nil)
+}
+
+// newTranslator 创建翻译器，loader 提供已加载的模块和消息用于解析命名空间
+func newTranslator(bundle *i18n.Bundle, cache internal.CacheManager, pool internal.PoolManager, config Config, loader *internal.LocaleLoader) *translator {
 	return &translator{
-		bundle: bundle,
+		state:  &localeState{bundle: bundle, loader: loader},
 		cache:  cache,
 		pool:   pool,
 		config: config,
+
+		fallbacks: internal.NewFallbackChains(config.FallbackChains, config.FallbackLanguage),
+		missing:   internal.NewMissingRegistry(config.MissingLimit),
+	}
+}
+
+// currentState 返回当前使用的已加载消息
+func (t *translator) currentState() *localeState {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.state
+}
+
+// isCurrent 检查 state 是否仍是当前使用的消息，调用方需持有读锁
+func (t *translator) isCurrent(state *localeState) bool {
+	return t.state == state
+}
+
+// setLocales 替换为重新加载的消息，同时清空 Localizer 对象池和翻译缓存
+// 替换前开始的翻译继续使用原来的消息，但结果不会写入缓存，Localizer 也不会归还到对象池
+func (t *translator) setLocales(bundle *i18n.Bundle, loader *internal.LocaleLoader) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state = &localeState{bundle: bundle, loader: loader}
+	if t.pool != nil {
+		t.pool.Reset(bundle)
+	}
+	if t.cache != nil {
+		t.cache.Clear()
 	}
 }
 
@@ -48,8 +106,98 @@ func (t *translator) Translate(ctx context.Context, messageID string, templateDa
 	return t.TranslateWithLanguage(ctx, lang, messageID, templateData...)
 }
 
+// NamespaceSeparator 命名空间与消息ID之间的分隔符，如 "errors:NOT_FOUND"
+const NamespaceSeparator = internal.NamespaceSeparator
+
+// resolveMessageID 解析消息ID的命名空间（仅在启用 LocaleConfig.Namespaces 时）
+//
+//	errors:NOT_FOUND -> errors:NOT_FOUND
+//	errors.NOT_FOUND -> errors:NOT_FOUND（errors 为已加载的模块时）
+//	NOT_FOUND        -> 依次尝试上下文命名空间、DefaultNamespace、唯一定义该消息的模块，最后使用原始ID
+func (t *translator) resolveMessageID(ctx context.Context, loader *internal.LocaleLoader, messageID string) string {
+	if !t.config.LocaleConfig.Namespaces || strings.Contains(messageID, NamespaceSeparator) {
+		return messageID
+	}
+
+	if module, id, ok := strings.Cut(messageID, "."); ok && t.isNamespace(loader, module) {
+		return module + NamespaceSeparator + id
+	}
+
+	for _, namespace := range []string{GetNamespaceFromContext(ctx), t.config.LocaleConfig.DefaultNamespace} {
+		if namespace == "" {
+			continue
+		}
+		namespaced := namespace + NamespaceSeparator + messageID
+		if loader == nil || loader.HasMessage(namespaced) {
+			return namespaced
+		}
+	}
+
+	if loader != nil {
+		if namespaces := loader.MessageNamespaces(messageID); len(namespaces) == 1 {
+			return namespaces[0] + NamespaceSeparator + messageID
+		}
+	}
+	return messageID
+}
+
+// isNamespace 检查名称是否为模块命名空间
+func (t *translator) isNamespace(loader *internal.LocaleLoader, name string) bool {
+	if loader != nil {
+		return loader.HasModule(name)
+	}
+	for _, module := range t.config.LocaleConfig.Modules {
+		if module == name {
+			return true
+		}
+	}
+	return false
+}
+
 // TranslateWithLanguage 使用指定语言翻译
 func (t *translator) TranslateWithLanguage(ctx context.Context, lang, messageID string, templateData ...map[string]interface{}) string {
+	return t.translate(ctx, lang, messageID, nil, templateData)
+}
+
+// Pluralize 复数翻译，按 count 和语言的复数规则选择消息的复数形式
+func (t *translator) Pluralize(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string {
+	lang := GetLanguageFromContext(ctx)
+	return t.translate(ctx, lang, messageID, count, templateData)
+}
+
+// TranslateE 翻译文本并返回翻译错误（*TranslationError），出错时仍然返回与 Translate 相同的降级结果
+func (t *translator) TranslateE(ctx context.Context, messageID string, templateData ...map[string]interface{}) (string, error) {
+	lang := GetLanguageFromContext(ctx)
+	return t.translateE(ctx, lang, messageID, nil, templateData)
+}
+
+// TranslateWithLanguageE 使用指定语言翻译并返回翻译错误
+func (t *translator) TranslateWithLanguageE(ctx context.Context, lang, messageID string, templateData ...map[string]interface{}) (string, error) {
+	return t.translateE(ctx, lang, messageID, nil, templateData)
+}
+
+// PluralizeE 复数翻译并返回翻译错误，缺少复数形式时返回 other 形式和 ErrPluralForm
+func (t *translator) PluralizeE(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) (string, error) {
+	lang := GetLanguageFromContext(ctx)
+	return t.translateE(ctx, lang, messageID, count, templateData)
+}
+
+// Direction 返回语言的文本方向
+func (t *translator) Direction(lang string) Direction {
+	return LanguageDirection(lang)
+}
+
+// translate 翻译消息，严格模式（Config.Strict）下翻译出错时 panic
+func (t *translator) translate(ctx context.Context, lang, messageID string, count interface{}, templateData []map[string]interface{}) string {
+	translated, err := t.translateE(ctx, lang, messageID, count, templateData)
+	if err != nil && t.config.Strict {
+		panic(err)
+	}
+	return translated
+}
+
+// translateE 翻译消息，count 不为空时按复数翻译；没有错误、也没有缺失翻译的结果按语言、消息ID、复数计数和模板数据缓存
+func (t *translator) translateE(ctx context.Context, lang, messageID string, count interface{}, templateData []map[string]interface{}) (string, error) {
 	start := time.Now()
 	defer func() {
 		if t.config.EnableMetrics {
@@ -57,10 +205,90 @@ func (t *translator) TranslateWithLanguage(ctx context.Context, lang, messageID
 		}
 	}()
 
-	// 构建缓存键
-	cacheKey := t.buildCacheKey(lang, messageID, templateData)
+	state := t.currentState()
+	messageID = t.resolveMessageID(ctx, state.loader, messageID)
+	variants := messageVariants(ctx, templateData)
+
+	// 伪本地化语言使用默认语言的消息，执行模板前伪本地化其中的文本
+	sourceLang := lang
+	pseudo, isPseudo := t.pseudoLocale(lang)
+	if isPseudo {
+		sourceLang = t.sourceLanguage()
+	}
+
+	var volatile bool
+	config := &i18n.LocalizeConfig{
+		MessageID:   messageID,
+		PluralCount: count,
+		Funcs:       templateFuncs(sourceLang, GetTimeZoneFromContext(ctx), &volatile),
+	}
+	if isPseudo {
+		config.TemplateParser = &internal.PseudoParser{Locale: pseudo, Funcs: config.Funcs}
+	}
+	if len(templateData) > 0 {
+		config.TemplateData = templateData[0]
+	}
+
+	var err error
+	cacheID := strings.Join(append([]string{messageID}, variants...), internal.ContextSeparator)
+	translated := t.cached(state, internal.BuildCacheKey(cacheLanguage(ctx, lang), cacheID, count, templateData), func() (string, bool) {
+		var translated string
+		var missed bool
+		translated, missed, err = t.doTranslate(ctx, state, sourceLang, config, variants)
+		return translated, !volatile && !missed && err == nil
+	})
+	return translated, err
+}
+
+// pseudoLocale 检查语言是否为伪本地化语言（en-XA、ar-XB），仅在启用 Config.PseudoLocalization 时生效
+func (t *translator) pseudoLocale(lang string) (internal.PseudoLocale, bool) {
+	if !t.config.PseudoLocalization {
+		return "", false
+	}
+	return internal.ParsePseudoLocale(lang)
+}
 
-	// 尝试从缓存获取
+// sourceLanguage 源语言，即默认语言（未配置时为降级语言），伪本地化和消息校验以源语言的消息为准
+func (t *translator) sourceLanguage() string {
+	if t.config.DefaultLanguage != "" {
+		return t.config.DefaultLanguage
+	}
+	return t.config.FallbackLanguage
+}
+
+// VariantDataKey 模板数据中指定消息变体的键，值为字符串或字符串切片，优先于上下文中的变体
+//
+//	i18n.T(ctx, "WELCOME", map[string]interface{}{"Variant": "female", "Name": "Ann"})
+const VariantDataKey = "Variant"
+
+// ContextSeparator 消息ID与变体之间的分隔符，语言文件中 "WELCOME#female" 等价于 {"id": "WELCOME", "context": "female"}
+const ContextSeparator = internal.ContextSeparator
+
+// messageVariants 获取本次翻译的消息变体，模板数据中的变体在前，上下文中的变体在后
+func messageVariants(ctx context.Context, templateData []map[string]interface{}) []string {
+	var variants []string
+	if len(templateData) > 0 {
+		switch v := templateData[0][VariantDataKey].(type) {
+		case string:
+			variants = append(variants, v)
+		case []string:
+			variants = append(variants, v...)
+		}
+	}
+	return append(variants, GetVariantsFromContext(ctx)...)
+}
+
+// cacheLanguage 缓存键中的语言部分，设置了时区时包含时区（日期按时区格式化）
+func cacheLanguage(ctx context.Context, lang string) string {
+	if loc := GetTimeZoneFromContext(ctx); loc != nil {
+		return lang + "@" + loc.String()
+	}
+	return lang
+}
+
+// cached 从缓存获取结果，未命中时调用 translate 并在结果可以缓存时存入缓存
+// 使用 relative 等随时间变化的模板函数的结果、报告了缺失翻译的结果，以及翻译期间消息已重新加载的结果不会被缓存
+func (t *translator) cached(state *localeState, cacheKey string, translate func() (string, bool)) string {
 	if t.cache != nil {
 		if cached, found := t.cache.Get(cacheKey); found {
 			internal.RecordCacheHit()
@@ -70,15 +298,14 @@ func (t *translator) TranslateWithLanguage(ctx context.Context, lang, messageID
 
 	internal.RecordCacheMiss()
 
-	// 获取 Localizer
-	loc := t.getLocalizer(lang)
-
-	// 执行翻译
-	result := t.doTranslate(loc, messageID, templateData...)
+	result, cacheable := translate()
 
-	// 存入缓存
-	if t.cache != nil {
-		t.cache.Set(cacheKey, result)
+	if t.cache != nil && cacheable {
+		t.mu.RLock()
+		if t.isCurrent(state) {
+			t.cache.Set(cacheKey, result)
+		}
+		t.mu.RUnlock()
 	}
 
 	return result
@@ -92,101 +319,284 @@ func (t *translator) Localizer(ctx context.Context) *i18n.Localizer {
 
 // LocalizerWithLanguage 获取指定语言的 Localizer
 func (t *translator) LocalizerWithLanguage(ctx context.Context, lang string) *i18n.Localizer {
-	return t.getLocalizer(lang)
+	return t.getLocalizer(t.currentState(), lang)
 }
 
 // LoadLocales 加载语言文件
+// 遍历 localesPath，按 LocaleConfig 的模式、语言和模块过滤发现的语言文件并加载，
+// 解析失败的文件会汇总到 *LocaleLoadError 中返回
 func (t *translator) LoadLocales(localesPath string) error {
-	// 这里简化实现，实际项目中应该遍历目录
-	// 支持的语言文件
-	supportedFiles := []string{
-		"en.json",
-		"zh-CN.json",
-		"zh-TW.json",
-		"ja.json",
-		"ko.json",
-		"fr.json",
-		"de.json",
-		"es.json",
-		"ru.json",
-	}
-
-	loadedCount := 0
-	for _, filename := range supportedFiles {
-		filePath := filepath.Join(localesPath, filename)
-		if _, err := t.bundle.LoadMessageFile(filePath); err == nil {
-			loadedCount++
+	return newLocaleLoader(t.config, localesPath, t.currentState().bundle).LoadLocales()
+}
+
+// 翻译错误的类型，使用 errors.Is 判断
+var (
+	ErrMessageNotFound = errors.New("message not found")
+	ErrTemplate        = errors.New("template execution failed")
+	ErrPluralForm      = errors.New("plural form missing")
+)
+
+// TranslationError 翻译错误，Kind 为 ErrMessageNotFound、ErrTemplate 或 ErrPluralForm
+type TranslationError struct {
+	Lang      string
+	MessageID string
+	Kind      error
+	Err       error
+}
+
+func (e *TranslationError) Error() string {
+	return fmt.Sprintf("i18n: translate %s (%s): %v: %v", e.MessageID, e.Lang, e.Kind, e.Err)
+}
+
+func (e *TranslationError) Unwrap() error {
+	return e.Err
+}
+
+// Is 使 errors.Is(err, ErrMessageNotFound) 等判断生效
+func (e *TranslationError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// newTranslationError 按 go-i18n 返回的错误判断翻译错误的类型
+// 复数消息的非模板错误（缺少复数形式、复数计数无效）归为 ErrPluralForm
+func newTranslationError(lang, messageID string, config *i18n.LocalizeConfig, err error) *TranslationError {
+	kind := ErrTemplate
+	var execErr texttemplate.ExecError
+	if !errors.As(err, &execErr) && config.PluralCount != nil {
+		kind = ErrPluralForm
+	}
+	return &TranslationError{Lang: lang, MessageID: messageID, Kind: kind, Err: err}
+}
+
+// LocaleFileError 单个语言文件的加载错误
+type LocaleFileError = internal.FileError
+
+// LocaleLoadError 语言文件加载错误，列出所有加载失败的文件
+type LocaleLoadError = internal.LoadError
+
+// newLocaleLoader 根据配置创建语言文件加载器
+func newLocaleLoader(config Config, localesPath string, bundle *i18n.Bundle) *internal.LocaleLoader {
+	return internal.NewLocaleLoader(localeLoaderConfig(config, localesPath), bundle)
+}
+
+// localeLoaderConfig 将服务配置转换为语言文件加载器配置
+func localeLoaderConfig(config Config, localesPath string) internal.LocaleLoaderConfig {
+	return internal.LocaleLoaderConfig{
+		Mode:          internal.LocaleMode(config.LocaleConfig.Mode),
+		Path:          localesPath,
+		Languages:     config.LocaleConfig.Languages,
+		Modules:       config.LocaleConfig.Modules,
+		Debug:         config.Debug,
+		FS:            config.FS,
+		KeySeparator:  config.LocaleConfig.KeySeparator,
+		Namespaces:    config.LocaleConfig.Namespaces,
+		MessageFormat: internal.MessageFormat(config.LocaleConfig.MessageFormat),
+		Sources:       config.MessageSources,
+	}
+}
+
+// getLocalizer 获取按语言降级链匹配的 Localizer（带池化）
+func (t *translator) getLocalizer(state *localeState, lang string) *i18n.Localizer {
+	if t.pool != nil {
+		if loc := t.pool.Get(lang); loc != nil {
+			return loc
+		}
+	}
+
+	if cached, ok := state.localizers.Load(lang); ok {
+		return cached.(*i18n.Localizer)
+	}
+	loc := i18n.NewLocalizer(state.bundle, t.fallbacks.Chain(lang)...)
+	state.localizers.Store(lang, loc)
+	return loc
+}
+
+// putLocalizer 将 Localizer 归还到对象池，消息已重新加载时丢弃
+func (t *translator) putLocalizer(state *localeState, lang string, loc *i18n.Localizer) {
+	if t.pool == nil {
+		return
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.isCurrent(state) {
+		t.pool.Put(lang, loc)
+	}
+}
+
+// doTranslate 执行实际翻译，按语言的降级链（如 zh-HK -> zh-TW -> en）依次查找消息
+// 某个语言中找到消息但缺少对应的复数形式时，使用该语言的 other 形式；缺少变体时使用该语言不带变体的消息；
+// 只有缺少整条消息时才继续查找降级链中的下一个语言
+// 消息只能从请求语言的降级链之外（通常是全局降级语言）取得或完全找不到时，报告缺失的翻译并返回 missed
+// 翻译出错时仍然返回降级结果，同时返回第一个 *TranslationError
+func (t *translator) doTranslate(ctx context.Context, state *localeState, lang string, config *i18n.LocalizeConfig, variants []string) (string, bool, error) {
+	messageID := config.MessageID
+
+	var firstErr error
+	var missed bool
+	for i, chainLang := range t.fallbacks.Chain(lang) {
+		loc := t.getLocalizer(state, chainLang)
+		translated, tag, err := t.localizeVariant(chainLang, loc, config, variants)
+		t.putLocalizer(state, chainLang, loc)
+
+		if err != nil && !isMessageNotFound(err) && firstErr == nil {
+			firstErr = newTranslationError(chainLang, messageID, config, err)
+		}
+
+		if err == nil || (translated != "" && !isMessageNotFound(err)) {
+			if !t.fallbacks.Inherits(lang, tag.String()) {
+				t.reportMissing(ctx, lang, messageID, tag.String())
+				missed = true
+			}
 			if t.config.Debug {
-				log.Printf("[i18n] Loaded locale file: %s", filename)
+				if err != nil {
+					log.Printf("[i18n] Incomplete translation for %s: %v", messageID, err)
+				}
+				if i > 0 {
+					log.Printf("[i18n] Used fallback translation for %s (%s)", messageID, chainLang)
+				}
 			}
-		} else if t.config.Debug {
-			log.Printf("[i18n] Failed to load %s: %v", filename, err)
+			return translated, missed, firstErr
+		}
+
+		// 翻译失败处理
+		if t.config.Debug {
+			log.Printf("[i18n] Translation failed for %s in %s: %v", messageID, chainLang, err)
 		}
 	}
 
-	if t.config.Debug {
-		log.Printf("[i18n] Loaded %d locale files from %s", loadedCount, localesPath)
+	if firstErr == nil {
+		t.reportMissing(ctx, lang, messageID, "")
+		missed = true
+		firstErr = &TranslationError{
+			Lang:      lang,
+			MessageID: messageID,
+			Kind:      ErrMessageNotFound,
+			Err:       fmt.Errorf("message %q not found in %s", messageID, strings.Join(t.fallbacks.Chain(lang), ", ")),
+		}
 	}
 
-	return nil
+	// 最后返回 messageID
+	return t.fallbackMessage(messageID), missed, firstErr
 }
 
-// buildCacheKey 构建缓存键
-func (t *translator) buildCacheKey(lang, messageID string, templateData []map[string]interface{}) string {
-	if len(templateData) == 0 {
-		return fmt.Sprintf("%s:%s", lang, messageID)
+// reportMissing 记录缺失的翻译并调用 MissingHandler
+func (t *translator) reportMissing(ctx context.Context, lang, messageID, fallback string) {
+	caller := callerLocation()
+	t.missing.Record(lang, messageID, caller)
+
+	if t.config.MissingHandler != nil {
+		t.config.MissingHandler(ctx, MissingTranslation{
+			Lang:      lang,
+			MessageID: messageID,
+			Caller:    caller,
+			Fallback:  fallback,
+		})
 	}
+}
 
-	// 对模板数据进行哈希
-	templateHash := md5.Sum([]byte(fmt.Sprintf("%v", templateData)))
-	return fmt.Sprintf("%s:%s:%x", lang, messageID, templateHash)
+// localizeVariant 依次查找消息的各个变体，都不存在时翻译不带变体的消息
+func (t *translator) localizeVariant(lang string, loc *i18n.Localizer, config *i18n.LocalizeConfig, variants []string) (string, language.Tag, error) {
+	for _, variant := range variants {
+		variantConfig := *config
+		variantConfig.MessageID = internal.VariantID(config.MessageID, variant)
+		if translated, tag, err := t.localizeOverride(lang, loc, &variantConfig); !isMessageNotFound(err) {
+			return translated, tag, err
+		}
+	}
+	return t.localizeOverride(lang, loc, config)
 }
 
-// getLocalizer 获取 Localizer（带池化）
-func (t *translator) getLocalizer(lang string) *i18n.Localizer {
-	if t.pool != nil {
-		return t.pool.Get(lang)
+// localizeOverride 优先使用该语言的运行时覆盖（见 Service.SetOverride），没有覆盖时使用语言文件中的消息
+func (t *translator) localizeOverride(lang string, loc *i18n.Localizer, config *i18n.LocalizeConfig) (string, language.Tag, error) {
+	if override := t.overrides.Localizer(lang); override != nil {
+		if translated, tag, err := t.localize(override, config); !isMessageNotFound(err) {
+			return translated, tag, err
+		}
 	}
-	return i18n.NewLocalizer(t.bundle, lang, t.config.FallbackLanguage)
+	return t.localize(loc, config)
 }
 
-// doTranslate 执行实际翻译
-func (t *translator) doTranslate(loc *i18n.Localizer, messageID string, templateData ...map[string]interface{}) string {
-	config := &i18n.LocalizeConfig{
-		MessageID: messageID,
+// localize 按 LocaleConfig.MessageFormat 使用 Go 模板或 ICU MessageFormat 翻译消息，同时返回消息所在的语言
+func (t *translator) localize(loc *i18n.Localizer, config *i18n.LocalizeConfig) (string, language.Tag, error) {
+	if internal.MessageFormat(t.config.LocaleConfig.MessageFormat) != internal.ICUFormat {
+		return loc.LocalizeWithTag(config)
 	}
 
-	if len(templateData) > 0 {
-		config.TemplateData = templateData[0]
+	// 先取得未经模板处理的消息和匹配到的语言，再按该语言的复数规则格式化
+	raw := *config
+	raw.TemplateParser = template.IdentityParser{}
+	raw.TemplateData = nil
+	pattern, tag, err := loc.LocalizeWithTag(&raw)
+	if err != nil && raw.PluralCount != nil && !isMessageNotFound(err) {
+		// ICU 消息通常只有 other 形式，复数由消息内的 plural 参数处理
+		raw.PluralCount = nil
+		pattern, tag, err = loc.LocalizeWithTag(&raw)
+	}
+	if pattern == "" {
+		return "", tag, err
 	}
 
-	translated, err := loc.Localize(config)
-	if err == nil {
-		return translated
+	msg, parseErr := t.parseICU(pattern)
+	if parseErr != nil {
+		return "", tag, parseErr
+	}
+	if pseudo, ok := config.TemplateParser.(*internal.PseudoParser); ok {
+		msg = msg.Pseudolocalize(pseudo.Locale)
 	}
 
-	// 翻译失败处理
-	if t.config.Debug {
-		log.Printf("[i18n] Translation failed for %s: %v", messageID, err)
+	args, _ := config.TemplateData.(map[string]interface{})
+	if config.PluralCount != nil {
+		args = withPluralCount(args, config.PluralCount)
 	}
+	// 与 go-i18n 一致，消息来自默认语言或默认消息时同时返回结果和 *i18n.MessageNotFoundErr
+	formatted, formatErr := msg.Format(tag, args)
+	if formatErr != nil {
+		return "", tag, formatErr
+	}
+	return formatted, tag, err
+}
 
-	// 尝试使用降级语言
-	if t.config.FallbackLanguage != "" {
-		fallbackLoc := i18n.NewLocalizer(t.bundle, t.config.FallbackLanguage)
-		if translated, err := fallbackLoc.Localize(config); err == nil {
-			if t.config.Debug {
-				log.Printf("[i18n] Used fallback translation for %s", messageID)
-			}
-			return translated
-		}
+// isMessageNotFound 检查是否为消息不存在错误
+func isMessageNotFound(err error) bool {
+	var notFound *i18n.MessageNotFoundErr
+	return errors.As(err, &notFound)
+}
+
+// parseICU 解析并缓存 ICU 消息
+func (t *translator) parseICU(pattern string) (*internal.ICUMessage, error) {
+	if cached, ok := t.icuMessages.Load(pattern); ok {
+		return cached.(*internal.ICUMessage), nil
 	}
 
-	// 最后返回 messageID
-	return t.fallbackMessage(messageID)
+	msg, err := internal.ParseICU(pattern)
+	if err != nil {
+		return nil, err
+	}
+	t.icuMessages.Store(pattern, msg)
+	return msg, nil
+}
+
+// withPluralCount 在模板数据中补充复数计数（count 参数），不修改原数据
+func withPluralCount(data map[string]interface{}, count interface{}) map[string]interface{} {
+	if _, exists := data["count"]; exists {
+		return data
+	}
+
+	args := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		args[k] = v
+	}
+	args["count"] = count
+	return args
 }
 
 // fallbackMessage 降级消息处理
 func (t *translator) fallbackMessage(messageID string) string {
+	// 去掉命名空间前缀
+	if i := strings.LastIndex(messageID, NamespaceSeparator); i >= 0 {
+		messageID = messageID[i+1:]
+	}
+
 	// 移除下划线，转换为更友好的格式
 	result := strings.ReplaceAll(messageID, "_", " ")
 
@@ -200,44 +610,38 @@ func (t *translator) fallbackMessage(messageID string) string {
 
 // 翻译辅助函数
 
-// Pluralize 复数翻译
-func (t *translator) Pluralize(ctx context.Context, messageID string, count interface{}, templateData ...map[string]interface{}) string {
+// TranslateTemplate 翻译模板字符串（不查找语言文件，直接按当前语言渲染模板）
+func (t *translator) TranslateTemplate(ctx context.Context, template string, templateData ...map[string]interface{}) string {
 	lang := GetLanguageFromContext(ctx)
-	loc := t.getLocalizer(lang)
-
-	config := &i18n.LocalizeConfig{
-		MessageID:    messageID,
-		PluralCount:  count,
-	}
-
-	if len(templateData) > 0 {
-		config.TemplateData = templateData[0]
-	}
-
-	if translated, err := loc.Localize(config); err == nil {
-		return translated
-	}
-
-	return t.fallbackMessage(messageID)
+	templateID := fmt.Sprintf("template:%x", md5.Sum([]byte(template)))
+
+	state := t.currentState()
+	return t.cached(state, internal.BuildCacheKey(cacheLanguage(ctx, lang), templateID, nil, templateData), func() (string, bool) {
+		var volatile bool
+		funcs := templateFuncs(lang, GetTimeZoneFromContext(ctx), &volatile)
+		return t.renderTemplate(state, lang, templateID, template, funcs, templateData), !volatile
+	})
 }
 
-// TranslateTemplate 翻译模板字符串
-func (t *translator) TranslateTemplate(ctx context.Context, template string, templateData ...map[string]interface{}) string {
-	lang := GetLanguageFromContext(ctx)
-	loc := t.getLocalizer(lang)
+// renderTemplate 渲染模板字符串，失败时进行简单的变量替换
+func (t *translator) renderTemplate(state *localeState, lang, templateID, template string, funcs texttemplate.FuncMap, templateData []map[string]interface{}) string {
+	loc := t.getLocalizer(state, lang)
+	defer t.putLocalizer(state, lang, loc)
 
 	config := &i18n.LocalizeConfig{
 		DefaultMessage: &i18n.Message{
-			ID:    "TEMPLATE",
+			ID:    templateID,
 			Other: template,
 		},
+		Funcs: funcs,
 	}
 
 	if len(templateData) > 0 {
 		config.TemplateData = templateData[0]
 	}
 
-	if translated, err := loc.Localize(config); err == nil {
+	// 模板不是语言文件中的消息，渲染默认消息时总会返回 *i18n.MessageNotFoundErr
+	if translated, _, err := t.localize(loc, config); err == nil || (translated != "" && isMessageNotFound(err)) {
 		return translated
 	}
 
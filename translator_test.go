@@ -0,0 +1,419 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/chenguowei/go-i18n/internal"
+)
+
+// writeLocaleFiles 在临时目录中写入语言文件
+func writeLocaleFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func newTestTranslator(config Config) Translator {
+	return NewTranslator(i18n.NewBundle(language.English), nil, nil, config)
+}
+
+func TestLoadLocalesReportsBrokenFiles(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `[{"id": "WELCOME", "translation": "Welcome"}]`,
+		"de.json": `[{"id": "WELCOME", "translation": `,
+	})
+
+	tr := newTestTranslator(Config{FallbackLanguage: "en"})
+	err := tr.LoadLocales(dir)
+	require.Error(t, err)
+
+	var loadErr *LocaleLoadError
+	require.True(t, errors.As(err, &loadErr))
+	require.Len(t, loadErr.Files, 1)
+	assert.Equal(t, "de", loadErr.Files[0].Lang)
+	assert.Equal(t, filepath.Join(dir, "de.json"), loadErr.Files[0].Path)
+
+	// 其余文件仍然会被加载
+	assert.Equal(t, "Welcome", tr.TranslateWithLanguage(context.Background(), "en", "WELCOME"))
+}
+
+func TestPluralize(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"FILES": {"one": "{{.Count}} file", "other": "{{.Count}} files"}, "ONLY_EN": {"one": "one item", "other": "{{.Count}} items"}}`,
+		"ru.json": `{"FILES": {"one": "{{.Count}} файл", "few": "{{.Count}} файла", "many": "{{.Count}} файлов", "other": "{{.Count}} файла"}}`,
+		"fr.json": `{"FILES": {"other": "{{.Count}} fichiers"}}`,
+	})
+
+	cache := internal.NewCacheManager(internal.CacheConfig{Enable: true, Size: 100, TTL: 60})
+	tr := NewTranslator(i18n.NewBundle(language.English), cache, nil, Config{FallbackLanguage: "en"})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	ru := SetLanguageToContext(context.Background(), "ru")
+	for count, want := range map[int]string{1: "1 файл", 3: "3 файла", 5: "5 файлов"} {
+		// 缓存键包含复数计数，不同的计数不会命中彼此的结果
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, want, tr.Pluralize(ru, "FILES", count, map[string]interface{}{"Count": count}))
+		}
+	}
+
+	// 缺少复数形式时使用同一语言的 other 形式，而不是降级语言
+	fr := SetLanguageToContext(context.Background(), "fr")
+	assert.Equal(t, "1 fichiers", tr.Pluralize(fr, "FILES", 1, map[string]interface{}{"Count": 1}))
+
+	// 缺少消息时使用降级语言及其复数规则
+	assert.Equal(t, "one item", tr.Pluralize(ru, "ONLY_EN", 1))
+	assert.Equal(t, "2 items", tr.Pluralize(ru, "ONLY_EN", 2, map[string]interface{}{"Count": 2}))
+	assert.Equal(t, "NOT FOUND", tr.Pluralize(ru, "NOT_FOUND", 2))
+}
+
+func TestTranslateTemplate(t *testing.T) {
+	tr := newTestTranslator(Config{FallbackLanguage: "en"})
+	ctx := SetLanguageToContext(context.Background(), "de")
+
+	assert.Equal(t, "Hallo Ann", tr.TranslateTemplate(ctx, "Hallo {{.Name}}", map[string]interface{}{"Name": "Ann"}))
+	assert.Equal(t, "HELLO ANN", tr.TranslateTemplate(ctx, `{{.Name | printf "HELLO %s"}}`, map[string]interface{}{"Name": "ANN"}))
+}
+
+func TestTranslateFormatFuncs(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"ORDER_TOTAL": "{{num .Count}} items, total {{currency .Total \"EUR\"}}"}`,
+		"de.json": `{"ORDER_TOTAL": "{{num .Count}} Artikel, Summe {{currency .Total \"EUR\"}}"}`,
+	})
+
+	tr := newTestTranslator(Config{FallbackLanguage: "en"})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	data := map[string]interface{}{"Count": 1500, "Total": 1234.5}
+	assert.Equal(t, "1,500 items, total €1,234.50", tr.TranslateWithLanguage(context.Background(), "en", "ORDER_TOTAL", data))
+	assert.Equal(t, "1.500 Artikel, Summe 1.234,50 €", tr.TranslateWithLanguage(context.Background(), "de", "ORDER_TOTAL", data))
+
+	zh := SetLanguageToContext(context.Background(), "zh-CN")
+	assert.Equal(t, "1,234.5", FormatNumber(zh, 1234.5))
+	assert.Equal(t, "￥1,234.50", FormatCurrency(zh, 1234.5, "CNY"))
+	assert.Equal(t, "12%", FormatPercent(zh, 0.12))
+	assert.Equal(t, "abc", FormatNumber(zh, "abc"))
+}
+
+func TestTranslateUnitFuncs(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json":    `{"TRIP": "{{list .Names}} drove {{unit .Distance \"kilometer\"}} in {{duration .Elapsed 2}}"}`,
+		"zh-CN.json": `{"TRIP": "{{list .Names}}用{{duration .Elapsed 2}}行驶了{{unit .Distance \"kilometer\"}}"}`,
+	})
+
+	tr := newTestTranslator(Config{FallbackLanguage: "en"})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	data := map[string]interface{}{
+		"Names":    []string{"Ann", "Bob", "Cy"},
+		"Distance": 5,
+		"Elapsed":  2*time.Hour + 5*time.Minute + 30*time.Second,
+	}
+	ctx := context.Background()
+	assert.Equal(t, "Ann, Bob, and Cy drove 5 kilometers in 2 hours 5 minutes", tr.TranslateWithLanguage(ctx, "en", "TRIP", data))
+	assert.Equal(t, "Ann, Bob and Cy drove 5 kilometres in 2 hours 5 minutes", tr.TranslateWithLanguage(ctx, "en-GB", "TRIP", data))
+	assert.Equal(t, "Ann、Bob和Cy用2小时5分钟行驶了5公里", tr.TranslateWithLanguage(ctx, "zh-CN", "TRIP", data))
+
+	ru := SetLanguageToContext(ctx, "ru")
+	assert.Equal(t, "A или B", FormatList(ru, []string{"A", "B"}, ListOr))
+	assert.Equal(t, "21 километр", FormatUnit(ru, 21, "kilometer"))
+	assert.Equal(t, "3 parsec", FormatUnit(ru, 3, "parsec"))
+	assert.Equal(t, "1 час", FormatDuration(ru, 90*time.Minute, 1))
+}
+
+func TestTranslateDateFuncs(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"LAST_LOGIN": "Last login: {{datetime .At \"short\"}}", "POSTED": "Posted {{relative .At}}"}`,
+		"de.json": `{"LAST_LOGIN": "Letzte Anmeldung: {{datetime .At \"short\"}}"}`,
+	})
+
+	cache := internal.NewCacheManager(internal.CacheConfig{Enable: true, Size: 100, TTL: 60})
+	tr := NewTranslator(i18n.NewBundle(language.English), cache, nil, Config{FallbackLanguage: "en"})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	at := time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC)
+	data := map[string]interface{}{"At": at}
+	ctx := context.Background()
+	assert.Equal(t, "Last login: 3/5/24, 2:07 PM", tr.TranslateWithLanguage(ctx, "en", "LAST_LOGIN", data))
+	assert.Equal(t, "Letzte Anmeldung: 05.03.24, 14:07", tr.TranslateWithLanguage(ctx, "de", "LAST_LOGIN", data))
+
+	// 时区是缓存键的一部分
+	tokyo := SetTimeZoneToContext(ctx, time.FixedZone("JST", 9*3600))
+	assert.Equal(t, "Letzte Anmeldung: 05.03.24, 23:07", tr.TranslateWithLanguage(tokyo, "de", "LAST_LOGIN", data))
+	assert.Equal(t, "Letzte Anmeldung: 05.03.24, 14:07", tr.TranslateWithLanguage(ctx, "de", "LAST_LOGIN", data))
+
+	// 相对时间使用请求语言，且结果不会被缓存
+	recent := map[string]interface{}{"At": time.Now().Add(-3 * time.Minute)}
+	assert.Equal(t, "Posted 3 minutes ago", tr.TranslateWithLanguage(ctx, "en", "POSTED", recent))
+	assert.Equal(t, "Posted vor 3 Minuten", tr.TranslateWithLanguage(ctx, "de", "POSTED", recent))
+	older := map[string]interface{}{"At": time.Now().Add(-3 * time.Hour)}
+	assert.Equal(t, "Posted 3 hours ago", tr.TranslateWithLanguage(ctx, "en", "POSTED", older))
+
+	zh := SetTimeZoneToContext(SetLanguageToContext(ctx, "zh-CN"), time.FixedZone("CST", 8*3600))
+	assert.Equal(t, "2024年3月5日 22:07:00", FormatDateTime(zh, at, DateMedium))
+	assert.Equal(t, "3分钟前", FormatRelative(zh, time.Now().Add(-3*time.Minute)))
+}
+
+func TestMessageVariants(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `[
+			{"id": "WELCOME", "translation": "Welcome, {{.Name}}"},
+			{"id": "WELCOME", "context": "female", "translation": "Welcome, Ms. {{.Name}}"},
+			{"id": "SIGNED_UP", "context": "female", "translation": "She signed up"},
+			{"id": "SIGNED_UP", "translation": "They signed up"}
+		]`,
+		"fr.json": `{"WELCOME": "Bienvenue, {{.Name}}", "WELCOME#female": "Bienvenue, Mme {{.Name}}", "INVITE": "Rejoins-nous", "INVITE#formal": "Rejoignez-nous"}`,
+		"de.yaml": "WELCOME: Willkommen, {{.Name}}\nINVITE: Komm vorbei\n",
+	})
+
+	cache := internal.NewCacheManager(internal.CacheConfig{Enable: true, Size: 100, TTL: 60})
+	tr := NewTranslator(i18n.NewBundle(language.English), cache, nil, Config{FallbackLanguage: "en"})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	ctx := context.Background()
+	female := SetVariantsToContext(ctx, "female")
+	data := map[string]interface{}{"Name": "Ann"}
+
+	assert.Equal(t, "Welcome, Ann", tr.TranslateWithLanguage(ctx, "en", "WELCOME", data))
+	assert.Equal(t, "Welcome, Ms. Ann", tr.TranslateWithLanguage(female, "en", "WELCOME", data))
+	assert.Equal(t, "Bienvenue, Mme Ann", tr.TranslateWithLanguage(female, "fr", "WELCOME", data))
+
+	// 语言缺少变体时使用该语言不带变体的消息，而不是降级语言的变体
+	assert.Equal(t, "Willkommen, Ann", tr.TranslateWithLanguage(female, "de", "WELCOME", data))
+
+	// 语言缺少整条消息时，在降级语言中同样先查找变体
+	assert.Equal(t, "She signed up", tr.TranslateWithLanguage(female, "fr", "SIGNED_UP"))
+	assert.Equal(t, "They signed up", tr.TranslateWithLanguage(ctx, "fr", "SIGNED_UP"))
+
+	// 模板数据中的变体优先于上下文，多个变体按顺序查找
+	formal := SetVariantsToContext(ctx, "female", "formal")
+	assert.Equal(t, "Rejoignez-nous", tr.TranslateWithLanguage(formal, "fr", "INVITE"))
+	assert.Equal(t, "Rejoignez-nous", tr.TranslateWithLanguage(ctx, "fr", "INVITE", map[string]interface{}{VariantDataKey: "formal"}))
+	assert.Equal(t, "Rejoins-nous", tr.TranslateWithLanguage(ctx, "fr", "INVITE"))
+	assert.Equal(t, "Bienvenue, Mme Ann", tr.TranslateWithLanguage(female, "fr", "WELCOME", map[string]interface{}{"Name": "Ann", VariantDataKey: []string{"male"}}))
+}
+
+func TestFallbackChains(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json":    `{"HELLO": "Hello", "BYE": "Bye", "THANKS": "Thanks"}`,
+		"zh-TW.json": `{"HELLO": "您好", "BYE": "再見"}`,
+		"zh-HK.json": `{"HELLO": "你好"}`,
+		"pt.json":    `{"HELLO": "Olá", "BYE": "Tchau"}`,
+		"pt-BR.json": `{"HELLO": "Oi"}`,
+	})
+
+	config := Config{
+		FallbackLanguage: "en",
+		FallbackChains:   map[string][]string{"zh-HK": {"zh-TW"}},
+	}
+	bundle := i18n.NewBundle(language.English)
+	pool := internal.NewPoolManager(internal.PoolConfig{Enable: true, Size: 10, FallbackLanguage: "en", FallbackChains: config.FallbackChains}, bundle)
+
+	for name, tr := range map[string]Translator{
+		"without pool": newTestTranslator(config),
+		"with pool":    NewTranslator(bundle, nil, pool, config),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, tr.LoadLocales(dir))
+
+			ctx := context.Background()
+			// 配置的降级链：zh-HK -> zh-TW -> en
+			assert.Equal(t, "你好", tr.TranslateWithLanguage(ctx, "zh-HK", "HELLO"))
+			assert.Equal(t, "再見", tr.TranslateWithLanguage(ctx, "zh-HK", "BYE"))
+			assert.Equal(t, "Thanks", tr.TranslateWithLanguage(ctx, "zh-HK", "THANKS"))
+
+			// CLDR 父语言：pt-BR -> pt -> en
+			assert.Equal(t, "Oi", tr.TranslateWithLanguage(ctx, "pt-BR", "HELLO"))
+			assert.Equal(t, "Tchau", tr.TranslateWithLanguage(ctx, "pt-BR", "BYE"))
+			assert.Equal(t, "Thanks", tr.TranslateWithLanguage(ctx, "pt-BR", "THANKS"))
+			assert.Equal(t, "Tchau", tr.TranslateWithLanguage(ctx, "pt-PT", "BYE"))
+		})
+	}
+}
+
+func TestMissingTranslations(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"HELLO": "Hello", "BYE": "Bye"}`,
+		"pt.json": `{"HELLO": "Olá"}`,
+	})
+
+	var reported []MissingTranslation
+	tr := newTranslator(i18n.NewBundle(language.English), nil, nil, Config{
+		FallbackLanguage: "en",
+		MissingHandler: func(ctx context.Context, missing MissingTranslation) {
+			reported = append(reported, missing)
+		},
+	}, nil)
+	require.NoError(t, tr.LoadLocales(dir))
+
+	ctx := context.Background()
+	assert.Equal(t, "Hello", tr.TranslateWithLanguage(ctx, "ko", "HELLO"))
+	assert.Equal(t, "Hello", tr.TranslateWithLanguage(ctx, "ko", "HELLO"))
+	assert.Equal(t, "Bye", tr.TranslateWithLanguage(ctx, "pt-BR", "BYE"))
+	assert.Equal(t, "USER NOT FOUND", tr.TranslateWithLanguage(ctx, "en", "USER_NOT_FOUND"))
+
+	// 使用请求语言或其父语言的翻译不算缺失
+	assert.Equal(t, "Olá", tr.TranslateWithLanguage(ctx, "pt-BR", "HELLO"))
+	assert.Equal(t, "Hello", tr.TranslateWithLanguage(ctx, "en-US", "HELLO"))
+
+	require.Len(t, reported, 4)
+	assert.Equal(t, MissingTranslation{Lang: "ko", MessageID: "HELLO", Caller: reported[0].Caller, Fallback: "en"}, reported[0])
+	assert.Contains(t, reported[0].Caller, "translator_test.go:")
+	assert.Equal(t, "", reported[3].Fallback)
+
+	records := tr.missing.Records()
+	require.Len(t, records, 3)
+	assert.Equal(t, "en", records[0].Lang)
+	assert.Equal(t, "USER_NOT_FOUND", records[0].MessageID)
+	assert.Equal(t, "ko", records[1].Lang)
+	assert.Equal(t, int64(2), records[1].Count)
+	assert.Len(t, records[1].Callers, 2)
+	assert.Equal(t, "BYE", records[2].MessageID)
+
+	tr.missing.Reset()
+	assert.Empty(t, tr.missing.Records())
+}
+
+func TestMissingTranslationsWithCache(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"HELLO": "Hello"}`,
+		"ko.json": `{"BYE": "안녕히 가세요"}`,
+	})
+
+	var reported int
+	cache := internal.NewCacheManager(internal.CacheConfig{Enable: true, Size: 100, TTL: 60})
+	tr := newTranslator(i18n.NewBundle(language.English), cache, nil, Config{
+		FallbackLanguage: "en",
+		MissingHandler: func(ctx context.Context, missing MissingTranslation) {
+			reported++
+		},
+	}, nil)
+	require.NoError(t, tr.LoadLocales(dir))
+
+	// 报告了缺失的结果不会被缓存，每次调用都会记录
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "Hello", tr.TranslateWithLanguage(ctx, "ko", "HELLO"))
+	}
+	assert.Equal(t, "Hello", tr.TranslateWithLanguage(ctx, "ko", "HELLO"))
+	assert.Equal(t, 4, reported)
+
+	records := tr.missing.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(4), records[0].Count)
+	assert.Len(t, records[0].Callers, 2)
+
+	// 没有缺失的结果照常缓存
+	assert.Equal(t, "안녕히 가세요", tr.TranslateWithLanguage(ctx, "ko", "BYE"))
+	_, found := cache.Get(internal.BuildCacheKey("ko", "BYE", nil, nil))
+	assert.True(t, found)
+	_, found = cache.Get(internal.BuildCacheKey("ko", "HELLO", nil, nil))
+	assert.False(t, found)
+}
+
+func TestTranslateE(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"HELLO": "Hello {{.Name}}", "BROKEN": "{{.Name.Missing}}", "FILES": {"one": "{{.Count}} file", "other": "{{.Count}} files"}}`,
+		"ru.json": `{"FILES": {"one": "{{.Count}} файл", "other": "{{.Count}} файла"}}`,
+	})
+
+	cache := internal.NewCacheManager(internal.CacheConfig{Enable: true, Size: 100, TTL: 60})
+	tr := NewTranslator(i18n.NewBundle(language.English), cache, nil, Config{FallbackLanguage: "en"})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	ctx := SetLanguageToContext(context.Background(), "en")
+	translated, err := tr.TranslateE(ctx, "HELLO", map[string]interface{}{"Name": "Ann"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Ann", translated)
+
+	// 出错的结果不会被缓存，再次调用仍然返回错误
+	for i := 0; i < 2; i++ {
+		translated, err = tr.TranslateE(ctx, "USER_NOT_FOUND")
+		assert.Equal(t, "USER NOT FOUND", translated)
+		assert.ErrorIs(t, err, ErrMessageNotFound)
+	}
+
+	var translationErr *TranslationError
+	require.ErrorAs(t, err, &translationErr)
+	assert.Equal(t, "en", translationErr.Lang)
+	assert.Equal(t, "USER_NOT_FOUND", translationErr.MessageID)
+
+	_, err = tr.TranslateE(ctx, "BROKEN", map[string]interface{}{"Name": "Ann"})
+	assert.ErrorIs(t, err, ErrTemplate)
+
+	// ru 的 5 需要 many 形式，缺少时返回 other 形式和错误
+	translated, err = tr.PluralizeE(SetLanguageToContext(context.Background(), "ru"), "FILES", 5, map[string]interface{}{"Count": 5})
+	assert.Equal(t, "5 файла", translated)
+	assert.ErrorIs(t, err, ErrPluralForm)
+
+	translated, err = tr.TranslateWithLanguageE(ctx, "ru", "HELLO", map[string]interface{}{"Name": "Ann"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello Ann", translated)
+}
+
+func TestStrictMode(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"HELLO": "Hello"}`,
+	})
+
+	tr := newTestTranslator(Config{FallbackLanguage: "en", Strict: true})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	ctx := context.Background()
+	assert.Equal(t, "Hello", tr.TranslateWithLanguage(ctx, "de", "HELLO"))
+	assert.PanicsWithError(t, `i18n: translate USER_NOT_FOUND (de): message not found: message "USER_NOT_FOUND" not found in de, en`, func() {
+		tr.TranslateWithLanguage(ctx, "de", "USER_NOT_FOUND")
+	})
+
+	// E 系列函数不受严格模式影响
+	_, err := tr.TranslateWithLanguageE(ctx, "de", "USER_NOT_FOUND")
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+}
+
+func TestPseudoLocalization(t *testing.T) {
+	dir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"HELLO": "Hello {{.Name}}", "FILES": {"one": "{{.Count}} file", "other": "{{.Count}} files"}}`,
+		"fr.json": `{"HELLO": "Bonjour {{.Name}}"}`,
+	})
+
+	cache := internal.NewCacheManager(internal.CacheConfig{Enable: true, Size: 100, TTL: 60})
+	tr := NewTranslator(i18n.NewBundle(language.English), cache, nil, Config{DefaultLanguage: "en", FallbackLanguage: "en", PseudoLocalization: true})
+	require.NoError(t, tr.LoadLocales(dir))
+
+	ctx := context.Background()
+	data := map[string]interface{}{"Name": "Ann"}
+	assert.Equal(t, "[Ĥéļļö Ann one]", tr.TranslateWithLanguage(ctx, "en-XA", "HELLO", data))
+	assert.Equal(t, "\u200f\u202eHello\u202c\u200f Ann", tr.TranslateWithLanguage(ctx, "ar-XB", "HELLO", data))
+	assert.Equal(t, "Hello Ann", tr.TranslateWithLanguage(ctx, "en", "HELLO", data))
+
+	xa := SetLanguageToContext(ctx, "en-XA")
+	assert.Equal(t, "[1 ƒîļé one]", tr.Pluralize(xa, "FILES", 1, map[string]interface{}{"Count": 1}))
+	assert.Equal(t, "[2 ƒîļéš one]", tr.Pluralize(xa, "FILES", 2, map[string]interface{}{"Count": 2}))
+
+	// 未启用时伪本地化语言按普通语言处理
+	plain := NewTranslator(i18n.NewBundle(language.English), nil, nil, Config{DefaultLanguage: "en", FallbackLanguage: "en"})
+	require.NoError(t, plain.LoadLocales(dir))
+	assert.Equal(t, "Hello Ann", plain.TranslateWithLanguage(ctx, "en-XA", "HELLO", data))
+
+	icuDir := writeLocaleFiles(t, map[string]string{
+		"en.json": `{"FILES": "{count, plural, one {# file} other {# files}}"}`,
+	})
+	icu := NewTranslator(i18n.NewBundle(language.English), nil, nil, Config{DefaultLanguage: "en", FallbackLanguage: "en", LocaleConfig: LocaleConfig{MessageFormat: "icu"}, PseudoLocalization: true})
+	require.NoError(t, icu.LoadLocales(icuDir))
+	assert.Equal(t, "[1,200 ƒîļéš one]", icu.Pluralize(xa, "FILES", 1200, map[string]interface{}{"count": 1200}))
+}